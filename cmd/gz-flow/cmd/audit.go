@@ -0,0 +1,192 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/spf13/cobra"
+
+	"github.com/gizzahub/gzh-cli-gitflow/internal/gitcmd"
+	"github.com/gizzahub/gzh-cli-gitflow/internal/validator"
+	"github.com/gizzahub/gzh-cli-gitflow/pkg/config"
+)
+
+var auditCmd = &cobra.Command{
+	Use:   "audit [type]",
+	Short: "Report stale, misnamed and merged flow branches",
+	Long: `Audit the local flow branches and report:
+
+  - Stale branches: not merged, no commit for more than --stale-days
+  - Naming violations: feature names that break guardian.naming
+  - Merged branches: already merged into their target but not deleted
+
+Each entry shows the author of the last commit. Use --max-issues in CI
+to fail (exit code 9) when the report finds more issues than allowed.
+
+Example:
+  gz-flow audit
+  gz-flow audit feature --stale-days 14
+  gz-flow audit -o json --max-issues 0`,
+	Args: cobra.MaximumNArgs(1),
+	RunE: runAudit,
+}
+
+var (
+	staleDays int
+	maxIssues int
+)
+
+// auditReport is the structured output of audit
+type auditReport struct {
+	StaleDays int               `json:"stale_days" yaml:"stale_days"`
+	Stale     []flowBranch      `json:"stale" yaml:"stale"`
+	Naming    []namingViolation `json:"naming" yaml:"naming"`
+	Merged    []flowBranch      `json:"merged" yaml:"merged"`
+	Healthy   int               `json:"healthy" yaml:"healthy"`
+	Issues    int               `json:"issues" yaml:"issues"`
+	Error     string            `json:"error,omitempty" yaml:"error,omitempty"`
+	ExitCode  int               `json:"exit_code" yaml:"exit_code"`
+}
+
+// setError records the failure of the command in the report
+func (r *auditReport) setError(err error) {
+	r.Error = err.Error()
+	r.ExitCode = ExitCode(err)
+}
+
+// namingViolation is a flow branch whose name breaks guardian.naming
+type namingViolation struct {
+	Branch    string `json:"branch" yaml:"branch"`
+	Author    string `json:"author" yaml:"author"`
+	Error     string `json:"error" yaml:"error"`
+	Suggested string `json:"suggested,omitempty" yaml:"suggested,omitempty"`
+}
+
+func init() {
+	rootCmd.AddCommand(auditCmd)
+
+	auditCmd.Flags().IntVar(&staleDays, "stale-days", 30, "Days without commits after which an unmerged branch is stale")
+	auditCmd.Flags().IntVar(&maxIssues, "max-issues", -1, "Fail when more issues are found (-1: never fail)")
+}
+
+func runAudit(cmd *cobra.Command, args []string) error {
+	if err := checkGitRepo(); err != nil {
+		return err
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 60*time.Second)
+	defer cancel()
+
+	git := gitcmd.New()
+	cfg, err := loadConfig()
+	if err != nil {
+		return err
+	}
+
+	if staleDays < 1 {
+		return usageError(fmt.Errorf("--stale-days must be at least 1"))
+	}
+	types := config.FlowTypes
+	if len(args) > 0 {
+		t, ok := config.ParseBranchType(args[0])
+		if !ok {
+			return usageError(fmt.Errorf("invalid branch type '%s'\n💡 Valid types: feature, release, hotfix", args[0]))
+		}
+		types = []config.BranchType{t}
+	}
+
+	// 1. Classify every flow branch
+	report := &auditReport{StaleDays: staleDays, Stale: []flowBranch{}, Naming: []namingViolation{}, Merged: []flowBranch{}}
+	result = report
+	for _, t := range types {
+		branches, err := collectFlowBranches(ctx, git, cfg, t)
+		if err != nil {
+			return err
+		}
+		for _, b := range branches {
+			healthy := true
+			switch {
+			case b.Merged:
+				report.Merged = append(report.Merged, b)
+				healthy = false
+			case b.Age() > time.Duration(staleDays)*24*time.Hour:
+				report.Stale = append(report.Stale, b)
+				healthy = false
+			}
+			// Only feature names are free-form; release and hotfix names are versions
+			if t == config.BranchFeature {
+				if v, ok := checkBranchName(cfg, b); !ok {
+					report.Naming = append(report.Naming, v)
+					healthy = false
+				}
+			}
+			if healthy {
+				report.Healthy++
+			}
+		}
+	}
+	report.Issues = len(report.Stale) + len(report.Naming) + len(report.Merged)
+
+	// 2. Print the report
+	printAudit(cfg, report)
+
+	// 3. Fail above the threshold
+	if maxIssues >= 0 && report.Issues > maxIssues {
+		return withExitCode(exitAudit, fmt.Errorf("audit found %d issue(s), more than the allowed %d", report.Issues, maxIssues))
+	}
+	return nil
+}
+
+// checkBranchName validates the name of a feature branch against guardian.naming
+func checkBranchName(cfg *config.Config, b flowBranch) (namingViolation, bool) {
+	name := strings.TrimPrefix(b.Name, cfg.Prefix(b.Type))
+	err := cfg.Guardian.Naming.Validate(name)
+	if err == nil {
+		return namingViolation{}, true
+	}
+	v := namingViolation{Branch: b.Name, Author: b.Author, Error: err.Error()}
+	if suggested := validator.SuggestBranchName(name); suggested != name && suggested != "" {
+		v.Suggested = cfg.Prefix(b.Type) + suggested
+	}
+	return v, false
+}
+
+// printAudit writes the human-readable audit report
+func printAudit(cfg *config.Config, report *auditReport) {
+	fmt.Fprintln(ui, "🔍 Branch Audit Report")
+	fmt.Fprintln(ui, "─────────────────────────────────────")
+
+	if len(report.Stale) > 0 {
+		fmt.Fprintf(ui, "⚠️  Stale branches (>%d days):\n", report.StaleDays)
+		for _, b := range report.Stale {
+			fmt.Fprintf(ui, "   %s (%s, %s)\n", b.Name, plural(int(b.Age()/(24*time.Hour)), "day"), b.Author)
+		}
+		fmt.Fprintln(ui)
+	}
+
+	if len(report.Naming) > 0 {
+		fmt.Fprintln(ui, "❌ Naming violations:")
+		for _, v := range report.Naming {
+			if v.Suggested != "" {
+				fmt.Fprintf(ui, "   %s → should be: %s (%s)\n", v.Branch, v.Suggested, v.Author)
+			} else {
+				fmt.Fprintf(ui, "   %s (%s)\n", v.Branch, v.Author)
+			}
+			fmt.Fprintf(ui, "      %s\n", v.Error)
+		}
+		fmt.Fprintln(ui)
+	}
+
+	if len(report.Merged) > 0 {
+		fmt.Fprintln(ui, "🧹 Merged but not deleted:")
+		for _, b := range report.Merged {
+			fmt.Fprintf(ui, "   %s → merged to %s (%s)\n", b.Name, cfg.FinishTarget(b.Type), b.Author)
+		}
+		fmt.Fprintln(ui)
+	}
+
+	fmt.Fprintf(ui, "📊 Summary: %d stale, %s, %d merged, %d healthy\n",
+		len(report.Stale), plural(len(report.Naming), "violation"), len(report.Merged), report.Healthy)
+}
@@ -0,0 +1,93 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/gizzahub/gzh-cli-gitflow/internal/gitcmd"
+	"github.com/gizzahub/gzh-cli-gitflow/pkg/config"
+)
+
+// flowBranch is a flow branch annotated with the metadata shown by list
+type flowBranch struct {
+	gitcmd.BranchInfo `yaml:",inline"`
+	Type              config.BranchType `json:"type" yaml:"type"`
+	Base              string            `json:"base" yaml:"base"`
+	Ahead             int               `json:"ahead" yaml:"ahead"`
+	Merged            bool              `json:"merged" yaml:"merged"`
+}
+
+// Age returns the time since the last commit on the branch
+func (b flowBranch) Age() time.Duration {
+	return time.Since(b.LastCommit)
+}
+
+// collectFlowBranches returns all local branches of the given type with metadata
+func collectFlowBranches(ctx context.Context, git *gitcmd.Executor, cfg *config.Config, t config.BranchType) ([]flowBranch, error) {
+	infos, err := git.ListBranchInfo(ctx, cfg.Prefix(t))
+	if err != nil {
+		return nil, fmt.Errorf("failed to list %s branches: %v", t, err)
+	}
+
+	base := cfg.BaseBranch(t)
+	target := cfg.FinishTarget(t)
+	baseExists, _ := git.BranchExists(ctx, base)
+	targetExists, _ := git.BranchExists(ctx, target)
+
+	branches := make([]flowBranch, 0, len(infos))
+	for _, info := range infos {
+		fb := flowBranch{BranchInfo: info, Type: t, Base: base}
+
+		if baseExists {
+			if fb.Ahead, _, err = git.AheadBehind(ctx, info.Name, base); err != nil {
+				return nil, fmt.Errorf("failed to compare %s with %s: %v", info.Name, base, err)
+			}
+		}
+		if targetExists {
+			if fb.Merged, err = git.IsMerged(ctx, info.Name, target); err != nil {
+				return nil, fmt.Errorf("failed to check if %s is merged: %v", info.Name, err)
+			}
+			// A branch never committed to is contained in its base, not merged
+			if fb.Merged && neverCommitted(ctx, git, info.Name) {
+				fb.Merged = false
+			}
+		}
+
+		branches = append(branches, fb)
+	}
+	return branches, nil
+}
+
+// neverCommitted reports whether the local branch still points at the commit
+// it was started from. Such a branch is reachable from its base without having
+// been merged into it; one merged by fast-forward has commits of its own.
+func neverCommitted(ctx context.Context, git *gitcmd.Executor, branch string) bool {
+	fresh, err := git.IsUnchangedSinceCreated(ctx, branch)
+	return err == nil && fresh
+}
+
+// humanizeAge formats a duration as a rough "N units ago" string
+func humanizeAge(d time.Duration) string {
+	switch {
+	case d < time.Minute:
+		return "just now"
+	case d < time.Hour:
+		return plural(int(d/time.Minute), "minute") + " ago"
+	case d < 24*time.Hour:
+		return plural(int(d/time.Hour), "hour") + " ago"
+	case d < 14*24*time.Hour:
+		return plural(int(d/(24*time.Hour)), "day") + " ago"
+	case d < 60*24*time.Hour:
+		return plural(int(d/(7*24*time.Hour)), "week") + " ago"
+	default:
+		return plural(int(d/(30*24*time.Hour)), "month") + " ago"
+	}
+}
+
+func plural(n int, unit string) string {
+	if n == 1 {
+		return fmt.Sprintf("1 %s", unit)
+	}
+	return fmt.Sprintf("%d %ss", n, unit)
+}
@@ -0,0 +1,161 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/spf13/cobra"
+
+	"github.com/gizzahub/gzh-cli-gitflow/internal/gitcmd"
+	"github.com/gizzahub/gzh-cli-gitflow/pkg/config"
+)
+
+var checkoutCmd = &cobra.Command{
+	Use:   "checkout [type]",
+	Short: "Choose a flow branch from a list and switch to it",
+	Long: `Show the active flow branches, most recently active first, with their
+age, commits ahead of their base and a stale marker, and switch to the
+one you choose.
+
+In a terminal the list is a menu: move with the arrow keys (or j/k),
+choose with enter, cancel with esc. Otherwise the branches are numbered
+and the number is read from stdin.
+
+With --autostash, uncommitted work is stashed before the switch and
+restored when you switch back to its branch with gz-flow.
+
+Example:
+  gz-flow checkout
+  gz-flow checkout feature --autostash
+  echo 2 | gz-flow checkout release`,
+	Args: cobra.MaximumNArgs(1),
+	RunE: runCheckout,
+}
+
+var autostash bool
+
+func init() {
+	rootCmd.AddCommand(checkoutCmd)
+
+	checkoutCmd.Flags().BoolVar(&autostash, "autostash", false, "Stash uncommitted work before switching; it is restored on return")
+}
+
+func runCheckout(cmd *cobra.Command, args []string) error {
+	report := beginReport("checkout", args)
+
+	if err := checkGitRepo(); err != nil {
+		return err
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+	defer cancel()
+
+	git := gitcmd.New()
+	cfg, err := loadConfig()
+	if err != nil {
+		return err
+	}
+
+	types := config.FlowTypes
+	if len(args) > 0 {
+		t, ok := config.ParseBranchType(args[0])
+		if !ok {
+			return usageError(fmt.Errorf("invalid branch type '%s'\n💡 Valid types: feature, release, hotfix", args[0]))
+		}
+		types = []config.BranchType{t}
+	}
+
+	// 1. Choose the branch; the selection itself changes nothing
+	branch, err := pickBranch(ctx, git, cfg, "Select branch to check out:", types...)
+	if err != nil {
+		return err
+	}
+	report.Branch = branch
+
+	// 2. Switch to it
+	return switchBranch(ctx, git, branch)
+}
+
+// autostashMessage names the stash entry holding the uncommitted work of branch
+func autostashMessage(branch string) string {
+	return "gz-flow autostash: " + branch
+}
+
+// switchPlan returns the steps that switch to branch. With --autostash the
+// uncommitted work is stashed first. Work stashed earlier for branch is
+// restored after the switch if the working tree is clean by then.
+func switchPlan(ctx context.Context, git *gitcmd.Executor, current, branch string) (gitcmd.Plan, error) {
+	clean, err := git.IsClean(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to check the working tree: %v", err)
+	}
+	entries, err := git.StashList(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list stash entries: %v", err)
+	}
+
+	var plan gitcmd.Plan
+	stashed := false
+	if !clean && autostash {
+		plan = append(plan, gitcmd.Step{Op: gitcmd.OpStash, Message: autostashMessage(current)})
+		stashed = true
+	}
+	plan = append(plan, gitcmd.Step{Op: gitcmd.OpCheckout, Branch: branch})
+
+	for i, entry := range entries {
+		if entry.Branch != branch || entry.Message != autostashMessage(branch) {
+			continue
+		}
+		if !clean && !stashed {
+			fmt.Fprintf(ui, "💡 Work stashed on '%s' is not restored while you have uncommitted changes\n\n", branch)
+			break
+		}
+		// A new stash entry moves the existing ones down by one
+		if stashed {
+			i++
+		}
+		plan = append(plan, gitcmd.Step{Op: gitcmd.OpStashPop, Stash: fmt.Sprintf("stash@{%d}", i)})
+		break
+	}
+	return plan, nil
+}
+
+// switchBranch checks out branch, stashing and restoring work as switchPlan
+// decides, and reports the steps in the running command's result
+func switchBranch(ctx context.Context, git *gitcmd.Executor, branch string) error {
+	current, _ := git.CurrentBranch(ctx)
+	if current == branch {
+		fmt.Fprintf(ui, "📍 Already on '%s'\n", branch)
+		return nil
+	}
+
+	plan, err := switchPlan(ctx, git, current, branch)
+	if err != nil {
+		return err
+	}
+	if dryRun {
+		return printPlan(plan, nil)
+	}
+	for _, step := range plan {
+		if err := git.Apply(ctx, step); err != nil {
+			opReport.Pending = plan[len(opReport.Completed):].Commands()
+			hint := "Commit your changes, or pass --autostash to stash them"
+			if step.Op == gitcmd.OpStashPop {
+				hint = "Resolve the conflicts; the stash entry is kept (git stash list)"
+			}
+			return fmt.Errorf("%s failed: %v\n💡 %s", step, err, hint)
+		}
+		opReport.Completed = append(opReport.Completed, step.String())
+
+		switch step.Op {
+		case gitcmd.OpStash:
+			fmt.Fprintf(ui, "📦 Stashed uncommitted work of '%s'\n", current)
+		case gitcmd.OpStashPop:
+			fmt.Fprintf(ui, "📦 Restored work stashed on '%s'\n", branch)
+		}
+	}
+
+	fmt.Fprintf(ui, "📍 Switched to branch '%s'\n", branch)
+	return nil
+}
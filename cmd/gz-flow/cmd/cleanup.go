@@ -0,0 +1,291 @@
+package cmd
+
+import (
+	"bufio"
+	"context"
+	"fmt"
+	"os"
+	"text/tabwriter"
+	"time"
+
+	"github.com/spf13/cobra"
+
+	"github.com/gizzahub/gzh-cli-gitflow/internal/gitcmd"
+	"github.com/gizzahub/gzh-cli-gitflow/pkg/config"
+)
+
+var cleanupCmd = &cobra.Command{
+	Use:   "cleanup [type]",
+	Short: "Delete flow branches that are already merged",
+	Long: `Delete feature, release and hotfix branches that are already merged
+into develop or master, e.g. after a finish with --keep or a merge
+through a web UI.
+
+A preview lists what will be deleted and what is kept. The deletion
+runs after you confirm it, or right away with --yes. The current
+branch, branches without commits of their own, and local branches the
+current branch does not contain (git branch -d refuses them) are kept.
+
+With --include-remote, merged branches on options.remote are deleted
+too. With --stale-days N, only branches without commits for N days
+are deleted, and unmerged branches that old are listed as stale.
+
+Example:
+  gz-flow cleanup --dry-run
+  gz-flow cleanup feature --yes
+  gz-flow cleanup --include-remote --stale-days 14`,
+	Args: cobra.MaximumNArgs(1),
+	RunE: runCleanup,
+}
+
+var (
+	cleanupYes    bool
+	includeRemote bool
+	cleanupStale  int
+)
+
+// cleanupCandidate is a flow branch considered by cleanup
+type cleanupCandidate struct {
+	gitcmd.BranchInfo
+	Remote string // empty for local branches
+	Reason string // why it is deleted or kept
+}
+
+// ref returns the name git resolves the candidate by
+func (c cleanupCandidate) ref() string {
+	if c.Remote != "" {
+		return c.Remote + "/" + c.Name
+	}
+	return c.Name
+}
+
+func init() {
+	rootCmd.AddCommand(cleanupCmd)
+
+	cleanupCmd.Flags().BoolVarP(&cleanupYes, "yes", "y", false, "Delete without asking for confirmation")
+	cleanupCmd.Flags().BoolVar(&includeRemote, "include-remote", false, "Also delete merged branches on the remote")
+	cleanupCmd.Flags().IntVar(&cleanupStale, "stale-days", 0, "Only delete branches without commits for this many days")
+}
+
+func runCleanup(cmd *cobra.Command, args []string) error {
+	report := beginReport("cleanup", args)
+
+	if err := checkGitRepo(); err != nil {
+		return err
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 60*time.Second)
+	defer cancel()
+
+	git := gitcmd.New()
+	cfg, err := loadConfig()
+	if err != nil {
+		return err
+	}
+
+	if cleanupStale < 0 {
+		return usageError(fmt.Errorf("--stale-days must not be negative"))
+	}
+	types := config.FlowTypes
+	if len(args) > 0 {
+		t, ok := config.ParseBranchType(args[0])
+		if !ok {
+			return usageError(fmt.Errorf("invalid branch type '%s'\n💡 Valid types: feature, release, hotfix", args[0]))
+		}
+		types = []config.BranchType{t}
+	}
+
+	// 1. Collect local and, if asked, remote flow branches
+	var remote string
+	if includeRemote {
+		if remote, err = resolveRemote(ctx, git, cfg); err != nil {
+			return err
+		}
+		fmt.Fprintf(ui, "🔄 Fetching '%s'...\n\n", remote)
+		if err := git.Fetch(ctx, remote); err != nil {
+			return fmt.Errorf("failed to fetch '%s': %v\n💡 Check your network connection, or run without --include-remote", remote, err)
+		}
+	}
+
+	var branches []cleanupCandidate
+	for _, t := range types {
+		infos, err := git.ListBranchInfo(ctx, cfg.Prefix(t))
+		if err != nil {
+			return fmt.Errorf("failed to list %s branches: %v", t, err)
+		}
+		for _, info := range infos {
+			branches = append(branches, cleanupCandidate{BranchInfo: info})
+		}
+		if remote == "" {
+			continue
+		}
+		infos, err = git.ListRemoteBranchInfo(ctx, remote, cfg.Prefix(t))
+		if err != nil {
+			return fmt.Errorf("failed to list %s branches on '%s': %v", t, remote, err)
+		}
+		for _, info := range infos {
+			branches = append(branches, cleanupCandidate{BranchInfo: info, Remote: remote})
+		}
+	}
+
+	// 2. Sort them into deleted, kept and stale
+	current, _ := git.CurrentBranch(ctx)
+	var remove, keep, stale []cleanupCandidate
+	for _, b := range branches {
+		into, err := mergedInto(ctx, git, cfg, b.ref())
+		if err != nil {
+			return err
+		}
+		// A branch never committed to is reachable from its base without being
+		// merged; keep it, and its remote copy while both point at the same commit
+		if into != "" && neverCommitted(ctx, git, b.Name) && (b.Remote == "" || sameCommit(ctx, git, b.Name, b.ref())) {
+			b.Reason = "no commits yet"
+			keep = append(keep, b)
+			continue
+		}
+		days := int(time.Since(b.LastCommit) / (24 * time.Hour))
+		switch {
+		case b.Remote == "" && b.Name == current:
+			b.Reason = "current branch"
+			keep = append(keep, b)
+		case into == "" && cleanupStale > 0 && days >= cleanupStale:
+			b.Reason = fmt.Sprintf("%s, no activity", plural(days, "day"))
+			stale = append(stale, b)
+		case into == "":
+		case cleanupStale > 0 && days < cleanupStale:
+			b.Reason = fmt.Sprintf("merged to %s, active %s", into, humanizeAge(time.Since(b.LastCommit)))
+			keep = append(keep, b)
+		case b.Remote == "" && !mergedIntoHead(ctx, git, b.Name):
+			// git branch -d only deletes branches the current branch contains
+			b.Reason = fmt.Sprintf("merged to %s; check out %s to delete it", into, into)
+			keep = append(keep, b)
+		default:
+			b.Reason = "merged to " + into
+			remove = append(remove, b)
+		}
+	}
+
+	// 3. Preview
+	var plan gitcmd.Plan
+	for _, b := range remove {
+		if b.Remote != "" {
+			plan = append(plan, gitcmd.Step{Op: gitcmd.OpDeleteRemoteBranch, Remote: b.Remote, Branch: b.Name})
+		} else {
+			// Plain -d, so git refuses anything not merged into HEAD
+			plan = append(plan, gitcmd.Step{Op: gitcmd.OpDeleteBranch, Branch: b.Name})
+		}
+	}
+	printCleanupPreview(remove, keep, stale)
+
+	if len(remove) == 0 {
+		fmt.Fprintln(ui, "✨ Nothing to clean up")
+		return nil
+	}
+	if dryRun {
+		report.Status = statusDryRun
+		report.Pending = plan.Commands()
+		fmt.Fprintln(ui, "💡 Run 'gz-flow cleanup' to execute")
+		return nil
+	}
+
+	// 4. Confirm
+	if !cleanupYes {
+		if !isInteractive() {
+			report.Pending = plan.Commands()
+			return usageError(fmt.Errorf("refusing to delete branches without confirmation\n💡 Pass --yes to delete them, or --dry-run to only preview"))
+		}
+		if !promptConfirm(bufio.NewReader(os.Stdin), fmt.Sprintf("Delete %s?", branchCount(len(remove))), false) {
+			report.Status = statusAborted
+			report.Pending = plan.Commands()
+			fmt.Fprintln(ui, "Nothing was deleted")
+			return nil
+		}
+		fmt.Fprintln(ui)
+	}
+
+	// 5. Delete, carrying on past failures
+	failed := 0
+	for _, step := range plan {
+		if err := git.Apply(ctx, step); err != nil {
+			fmt.Fprintf(ui, "❌ %s: %v\n", step, err)
+			report.Pending = append(report.Pending, step.String())
+			failed++
+			continue
+		}
+		fmt.Fprintf(ui, "🗑️  %s\n", step)
+		report.Completed = append(report.Completed, step.String())
+	}
+	fmt.Fprintln(ui)
+
+	if failed > 0 {
+		return fmt.Errorf("failed to delete %d of %s", failed, branchCount(len(plan)))
+	}
+	fmt.Fprintf(ui, "✅ Deleted %s\n", branchCount(len(plan)))
+	return nil
+}
+
+// mergedInto returns the branch ref is merged into, develop or master, or ""
+// if it is merged into neither
+func mergedInto(ctx context.Context, git *gitcmd.Executor, cfg *config.Config, ref string) (string, error) {
+	for _, target := range []string{cfg.Branches.Develop, cfg.Branches.Master} {
+		if exists, _ := git.BranchExists(ctx, target); !exists {
+			continue
+		}
+		merged, err := git.IsMerged(ctx, ref, target)
+		if err != nil {
+			return "", fmt.Errorf("failed to check if %s is merged: %v", ref, err)
+		}
+		if merged {
+			return target, nil
+		}
+	}
+	return "", nil
+}
+
+// sameCommit reports whether two refs point at the same commit
+func sameCommit(ctx context.Context, git *gitcmd.Executor, a, b string) bool {
+	shaA, errA := git.RevParse(ctx, a)
+	shaB, errB := git.RevParse(ctx, b)
+	return errA == nil && errB == nil && shaA == shaB
+}
+
+// mergedIntoHead reports whether the current branch contains branch
+func mergedIntoHead(ctx context.Context, git *gitcmd.Executor, branch string) bool {
+	merged, err := git.IsMerged(ctx, branch, "HEAD")
+	return err == nil && merged
+}
+
+// branchCount formats a number of branches
+func branchCount(n int) string {
+	if n == 1 {
+		return "1 branch"
+	}
+	return fmt.Sprintf("%d branches", n)
+}
+
+// printCleanupPreview lists what cleanup deletes and keeps
+func printCleanupPreview(remove, keep, stale []cleanupCandidate) {
+	fmt.Fprintln(ui, "🧹 Cleanup Preview")
+	fmt.Fprintln(ui, "─────────────────────────────────────")
+
+	sections := []struct {
+		title    string
+		branches []cleanupCandidate
+	}{
+		{"Will delete (already merged):", remove},
+		{"Will keep:", keep},
+		{"Will warn (stale, not merged):", stale},
+	}
+	for _, s := range sections {
+		if len(s.branches) == 0 {
+			continue
+		}
+		fmt.Fprintln(ui, s.title)
+		w := tabwriter.NewWriter(ui, 0, 0, 2, ' ', 0)
+		for _, b := range s.branches {
+			fmt.Fprintf(w, "  %s\t→ %s\n", b.ref(), b.Reason)
+		}
+		w.Flush()
+		fmt.Fprintln(ui)
+	}
+}
@@ -2,8 +2,11 @@ package cmd
 
 import (
 	"fmt"
+	"strings"
 
 	"github.com/spf13/cobra"
+
+	"github.com/gizzahub/gzh-cli-gitflow/pkg/config"
 )
 
 var configCmd = &cobra.Command{
@@ -15,65 +18,157 @@ Without arguments, shows current configuration.
 With key, shows the value of that key.
 With key and value, sets the configuration.
 
+Keys are dotted paths into the configuration file:
+  branches.*, prefixes.*, options.*, guardian.*
+List values (e.g. guardian.naming.forbidden) are comma-separated.
+
+Values are written to .gzflow.yaml, or to ~/.gz/gitflow with --global.
+Reads show the effective value after merging, in order: defaults,
+~/.gz/gitflow, .gzflow.yaml, GZFLOW_* environment variables, --config.
+
 Example:
   gz-flow config                      # Show all config
   gz-flow config branches.master      # Get master branch name
   gz-flow config branches.master main # Set master branch to 'main'
+  gz-flow config --unset branches.master
+  gz-flow config --list --show-origin # Show where each value comes from
   gz-flow config --global ...         # Modify global config`,
 	Args: cobra.MaximumNArgs(2),
 	RunE: runConfig,
 }
 
-var globalConfig bool
+var (
+	globalConfig bool
+	unsetConfig  bool
+	listConfig   bool
+	showOrigin   bool
+)
 
 func init() {
 	rootCmd.AddCommand(configCmd)
 
 	configCmd.Flags().BoolVarP(&globalConfig, "global", "g", false, "Use global configuration")
+	configCmd.Flags().BoolVar(&unsetConfig, "unset", false, "Remove a key from the configuration file")
+	configCmd.Flags().BoolVarP(&listConfig, "list", "l", false, "List all values as key=value")
+	configCmd.Flags().BoolVar(&showOrigin, "show-origin", false, "Show the layer and file each value comes from")
 }
 
 func runConfig(cmd *cobra.Command, args []string) error {
-	// TODO: Implement config logic
-	// 1. Load configuration
-	// 2. Handle get/set operations
-	// 3. Save if modified
-
-	if len(args) == 0 {
-		// Show all config
-		tagFormat := "v%s" //nolint:govet // literal string, not format
-		fmt.Println("Git-flow Configuration")
-		fmt.Println("======================")
-		fmt.Println("")
-		fmt.Println("Branches:")
-		fmt.Println("  master:  master")
-		fmt.Println("  develop: develop")
-		fmt.Println("")
-		fmt.Println("Prefixes:")
-		fmt.Println("  feature: feature/")
-		fmt.Println("  release: release/")
-		fmt.Println("  hotfix:  hotfix/")
-		fmt.Println("")
-		fmt.Println("Options:")
-		fmt.Println("  delete_branch_after_finish: true")
-		fmt.Println("  push_after_finish: false")
-		fmt.Println("  tag_format:", tagFormat)
+	path, err := configTargetPath()
+	if err != nil {
+		return err
+	}
+
+	// 1. Unset
+	if unsetConfig {
+		if len(args) != 1 {
+			return usageError(fmt.Errorf("--unset requires exactly one key\nUsage: gz-flow config --unset <key>"))
+		}
+		if err := config.UnsetInFile(path, args[0]); err != nil {
+			return err
+		}
+		result = configEntry{Key: args[0], File: path}
+		fmt.Fprintf(ui, "✅ Unset %s (%s)\n", args[0], path)
+		return nil
+	}
+
+	// 2. Set
+	if len(args) == 2 {
+		key, value := args[0], args[1]
+		if err := config.SetInFile(path, key, value); err != nil {
+			return err
+		}
+		result = configEntry{Key: key, Value: value, File: path}
+		fmt.Fprintf(ui, "✅ Set %s = %s (%s)\n", key, value, path)
 		return nil
 	}
 
-	key := args[0]
+	// 3. Load effective configuration (all layers)
+	opts := configLoadOptions()
+	if globalConfig {
+		// Only defaults and the global file
+		opts = config.LoadOptions{}
+	}
+	// Invalid values are shown too, so their origin can be found and fixed
+	opts.NoValidate = true
+	cfg, origins, err := config.LoadLayered(opts)
+	if err != nil {
+		return err
+	}
+	if err := cfg.Validate(); err != nil {
+		fmt.Fprintf(ui, "⚠️  %v\n\n", &config.InvalidError{Err: err, Origins: origins})
+	}
 
+	// 4. Get
 	if len(args) == 1 {
-		// Get value
-		fmt.Printf("%s = (value)\n", key)
+		value, err := cfg.Get(args[0])
+		if err != nil {
+			return err
+		}
+		result = configEntry{Key: args[0], Value: value, Origin: origins[args[0]].String()}
+		if showOrigin {
+			fmt.Fprintf(ui, "%s\t%s\n", origins[args[0]], value)
+		} else {
+			fmt.Fprintln(ui, value)
+		}
 		return nil
 	}
 
-	value := args[1]
-	configScope := "local"
-	if globalConfig {
-		configScope = "global"
+	report := &configReport{Config: []configEntry{}}
+	for _, key := range config.Keys() {
+		value, _ := cfg.Get(key)
+		report.Config = append(report.Config, configEntry{Key: key, Value: value, Origin: origins[key].String()})
+	}
+	result = report
+
+	// 5. List
+	if listConfig || showOrigin {
+		for _, key := range config.Keys() {
+			value, _ := cfg.Get(key)
+			if showOrigin {
+				fmt.Fprintf(ui, "%s\t%s=%s\n", origins[key], key, value)
+			} else {
+				fmt.Fprintf(ui, "%s=%s\n", key, value)
+			}
+		}
+		return nil
+	}
+
+	fmt.Fprintln(ui, "Git-flow Configuration")
+	fmt.Fprintln(ui, "======================")
+
+	section := ""
+	for _, key := range config.Keys() {
+		group, name, _ := strings.Cut(key, ".")
+		if group != section {
+			section = group
+			fmt.Fprintln(ui, "")
+			fmt.Fprintf(ui, "%s:\n", capitalize(group))
+		}
+		value, _ := cfg.Get(key)
+		fmt.Fprintf(ui, "  %s: %s\n", name, value)
 	}
 
-	fmt.Printf("Set %s = %s (%s)\n", key, value, configScope)
 	return nil
 }
+
+// configEntry is a single key of the structured config output
+type configEntry struct {
+	Key    string `json:"key" yaml:"key"`
+	Value  string `json:"value" yaml:"value"`
+	Origin string `json:"origin,omitempty" yaml:"origin,omitempty"`
+	File   string `json:"file,omitempty" yaml:"file,omitempty"` // file changed by set/unset
+}
+
+// configReport is the structured output of config and config --list
+type configReport struct {
+	Config []configEntry `json:"config" yaml:"config"`
+}
+
+// configTargetPath returns the file that set/unset operate on
+func configTargetPath() (string, error) {
+	if globalConfig {
+		return config.GlobalPath()
+	}
+	return config.LocalFileName, nil
+}
@@ -9,7 +9,6 @@ import (
 	"github.com/spf13/cobra"
 
 	"github.com/gizzahub/gzh-cli-gitflow/internal/gitcmd"
-	"github.com/gizzahub/gzh-cli-gitflow/internal/preflight"
 	"github.com/gizzahub/gzh-cli-gitflow/internal/validator"
 	"github.com/gizzahub/gzh-cli-gitflow/pkg/config"
 )
@@ -23,8 +22,12 @@ Feature branches are used to develop new features for the upcoming
 or a distant future release.
 
 Commands:
-  start   - Start a new feature branch from develop
-  finish  - Finish a feature branch (merge to develop)`,
+  start    - Start a new feature branch from develop
+  finish   - Finish a feature branch (merge to develop)
+  checkout - Switch to a feature branch by part of its name
+  publish  - Push a feature branch to the remote
+  track    - Check out a feature branch from the remote
+  pull     - Update a feature branch with remote changes`,
 }
 
 var featureStartCmd = &cobra.Command{
@@ -50,13 +53,41 @@ This will:
   - Merge the feature branch into develop
   - Delete the feature branch (unless --keep is specified)
 
+If the merge stops on a conflict, resolve it and run --continue,
+or run --abort to restore develop to where it was.
+
 Example:
   gz-flow feature finish user-authentication
-  gz-flow feature finish --auto  # Auto-detect from current branch`,
+  gz-flow feature finish  # Auto-detect from current branch
+  gz-flow feature finish --pick  # Choose from a list
+  gz-flow feature finish --continue`,
 	Args: cobra.MaximumNArgs(1),
 	RunE: runFeatureFinish,
 }
 
+var featureCheckoutCmd = &cobra.Command{
+	Use:     "checkout [query]",
+	Aliases: []string{"switch"},
+	Short:   "Switch to a feature branch by part of its name",
+	Long: `Switch to the feature branch whose name matches the query.
+
+A branch named exactly like the query wins. Otherwise the query matches
+branches containing it, or, if none does, branches containing its
+letters in order ("uav2" matches user-authentication-v2). With exactly
+one match gz-flow switches to it; with several it lists them. Without
+a query, the feature branches are shown as a list to choose from.
+
+With --autostash, uncommitted work is stashed before the switch and
+restored when you switch back to its branch with gz-flow.
+
+Example:
+  gz-flow feature checkout auth
+  gz-flow feature switch oauth --autostash
+  gz-flow feature checkout  # Choose from a list`,
+	Args: cobra.MaximumNArgs(1),
+	RunE: runFeatureCheckout,
+}
+
 var (
 	keepBranch bool
 	fromBranch string
@@ -67,13 +98,22 @@ func init() {
 
 	featureCmd.AddCommand(featureStartCmd)
 	featureCmd.AddCommand(featureFinishCmd)
+	featureCmd.AddCommand(featureCheckoutCmd)
+	featureCmd.AddCommand(newPublishCmd(config.BranchFeature))
+	featureCmd.AddCommand(newTrackCmd(config.BranchFeature))
+	featureCmd.AddCommand(newPullCmd(config.BranchFeature))
 
 	featureStartCmd.Flags().StringVar(&fromBranch, "from", "", "Base branch to start from (default: develop)")
 
 	featureFinishCmd.Flags().BoolVarP(&keepBranch, "keep", "k", false, "Keep the feature branch after finishing")
+	addFinishFlags(featureFinishCmd)
+
+	featureCheckoutCmd.Flags().BoolVar(&autostash, "autostash", false, "Stash uncommitted work before switching; it is restored on return")
 }
 
 func runFeatureStart(cmd *cobra.Command, args []string) error {
+	report := beginReport("feature start", args)
+
 	// 1. Check git repo
 	if err := checkGitRepo(); err != nil {
 		return err
@@ -83,29 +123,27 @@ func runFeatureStart(cmd *cobra.Command, args []string) error {
 	defer cancel()
 
 	git := gitcmd.New()
-	cfg, err := config.LoadFromDir(".")
+	cfg, err := loadConfig()
 	if err != nil {
-		fmt.Printf("⚠️  Failed to load config, using defaults: %v\n", err)
-		cfg = config.Default()
+		return err
 	}
 
 	// 2. Get branch name
 	if len(args) == 0 {
-		return fmt.Errorf("feature name is required\nUsage: gz-flow feature start <name>")
+		return usageError(fmt.Errorf("feature name is required\nUsage: gz-flow feature start <name>"))
 	}
 	name := args[0]
 
 	// 3. Validate branch name
 	if err := validator.ValidateBranchName(name); err != nil {
 		suggested := validator.SuggestBranchName(name)
-		return fmt.Errorf("invalid branch name: %v\n💡 Suggested: %s", err, suggested)
+		return usageError(fmt.Errorf("invalid branch name: %v\n💡 Suggested: %s", err, suggested))
 	}
 
 	// 4. Check Guardian rules if enabled
-	if cfg.Guardian.Enabled {
-		if err := cfg.Guardian.Naming.Validate(name); err != nil {
-			return fmt.Errorf("guardian: %v", err)
-		}
+	fullBranchName := cfg.Prefixes.Feature + name
+	if err := checkNaming(ctx, git, cfg, "feature start", name, fullBranchName); err != nil {
+		return err
 	}
 
 	// 5. Determine base branch
@@ -117,33 +155,44 @@ func runFeatureStart(cmd *cobra.Command, args []string) error {
 	// 6. Context hint: warn if not on expected branch
 	currentBranch, _ := git.CurrentBranch(ctx)
 	if currentBranch != baseBranch {
-		fmt.Printf("⚠️  You're on '%s', not '%s'\n", currentBranch, baseBranch)
-		fmt.Printf("💡 Will checkout '%s' first\n\n", baseBranch)
+		fmt.Fprintf(ui, "⚠️  You're on '%s', not '%s'\n", currentBranch, baseBranch)
+		fmt.Fprintf(ui, "💡 Will checkout '%s' first\n\n", baseBranch)
 	}
 
 	// 7. Check if branch already exists
-	fullBranchName := cfg.Prefixes.Feature + name
 	exists, _ := git.BranchExists(ctx, fullBranchName)
 	if exists {
 		return fmt.Errorf("branch '%s' already exists", fullBranchName)
 	}
 
-	// 8. Execute
-	if err := git.Checkout(ctx, baseBranch); err != nil {
-		return fmt.Errorf("failed to checkout %s: %v", baseBranch, err)
-	}
+	// 8. Uncommitted changes move to the new branch; see if other features touch them
+	carried, _ := git.ModifiedFiles(ctx)
 
-	if err := git.CreateBranch(ctx, fullBranchName); err != nil {
-		return fmt.Errorf("failed to create branch: %v", err)
+	// 9. Execute
+	plan := gitcmd.Plan{
+		{Op: gitcmd.OpCheckout, Branch: baseBranch},
+		{Op: gitcmd.OpCreateBranch, Branch: fullBranchName},
+	}
+	if dryRun {
+		return printPlan(plan, nil)
+	}
+	if err := git.Execute(ctx, plan); err != nil {
+		return err
 	}
+	report.Branch = fullBranchName
+	report.Completed = plan.Commands()
+
+	warnConflictRisk(ctx, git, cfg, fullBranchName, carried, "Coordinate with the authors before changing these files further")
 
-	fmt.Printf("✅ Started feature branch '%s'\n", fullBranchName)
-	fmt.Printf("📍 Switched to branch '%s'\n", fullBranchName)
+	fmt.Fprintf(ui, "✅ Started feature branch '%s'\n", fullBranchName)
+	fmt.Fprintf(ui, "📍 Switched to branch '%s'\n", fullBranchName)
 
 	return nil
 }
 
 func runFeatureFinish(cmd *cobra.Command, args []string) error {
+	beginReport(opFeatureFinish, args)
+
 	// 1. Check git repo
 	if err := checkGitRepo(); err != nil {
 		return err
@@ -153,13 +202,24 @@ func runFeatureFinish(cmd *cobra.Command, args []string) error {
 	defer cancel()
 
 	git := gitcmd.New()
-	cfg, err := config.LoadFromDir(".")
+	if resuming() {
+		return resumeFinish(ctx, git, opFeatureFinish)
+	}
+	if err := checkNoFinishInProgress(ctx, git); err != nil {
+		return err
+	}
+
+	cfg, err := loadConfig()
 	if err != nil {
-		fmt.Printf("⚠️  Failed to load config, using defaults: %v\n", err)
-		cfg = config.Default()
+		return err
 	}
 
 	// 2. Determine feature name
+	if pickFinish {
+		if args, err = pickArgs(ctx, git, config.BranchFeature, args); err != nil {
+			return err
+		}
+	}
 	var name string
 	if len(args) > 0 {
 		name = args[0]
@@ -175,50 +235,141 @@ func runFeatureFinish(cmd *cobra.Command, args []string) error {
 			return fmt.Errorf("not on a feature branch (current: %s)\n💡 Use 'gz-flow feature finish <name>' or switch to a feature branch", currentBranch)
 		}
 		name = strings.TrimPrefix(currentBranch, prefix)
-		fmt.Printf("📍 Auto-detected feature: %s\n\n", name)
+		opReport.Name = name
+		fmt.Fprintf(ui, "📍 Auto-detected feature: %s\n\n", name)
 	}
 
 	fullBranchName := cfg.Prefixes.Feature + name
 	targetBranch := cfg.Branches.Develop
 
-	// 3. Pre-flight checks
-	checker := preflight.NewChecker(git, targetBranch)
-	results := checker.RunAll(ctx)
+	// 3. Check source branch exists
+	exists, _ := git.BranchExists(ctx, fullBranchName)
+	if !exists {
+		return fmt.Errorf("feature branch '%s' does not exist", fullBranchName)
+	}
 
-	fmt.Println("🔍 Pre-flight checks:")
-	fmt.Print(results.String())
+	// 4. Pre-flight checks
+	results := runPreflight(ctx, git, cfg, opFeatureFinish, fullBranchName, targetBranch)
+	if results.HasErrors() && !dryRun {
+		return withExitCode(exitPreflight, fmt.Errorf("pre-flight checks failed"))
+	}
+	// Custom checks may outlast the timeout; restart it for the steps
+	ctx, cancel = context.WithTimeout(context.Background(), 30*time.Second)
+	defer cancel()
 
-	if results.HasErrors() {
-		return fmt.Errorf("pre-flight checks failed")
+	// 5. Warn about files other features change too; they conflict after this merge
+	if files, err := git.ChangedFiles(ctx, targetBranch, fullBranchName); err == nil {
+		warnConflictRisk(ctx, git, cfg, fullBranchName, files, "Let the authors know; they will need to merge develop after this finish")
 	}
-	fmt.Println()
 
-	// 4. Check source branch exists
-	exists, _ := git.BranchExists(ctx, fullBranchName)
-	if !exists {
-		return fmt.Errorf("feature branch '%s' does not exist", fullBranchName)
+	// 6. Merge into develop, then delete the branch if requested. Linear
+	// history fast-forwards develop to the rebased feature instead.
+	merge := gitcmd.Step{Op: gitcmd.OpMerge, Branch: fullBranchName, Into: targetBranch, NoFF: true}
+	if linearHistory(cfg, opFeatureFinish) {
+		merge.NoFF, merge.FFOnly = false, true
+	}
+	plan := gitcmd.Plan{
+		{Op: gitcmd.OpCheckout, Branch: targetBranch},
+		merge,
+	}
+	if cfg.Options.DeleteBranchAfterFinish && !keepBranch {
+		plan = append(plan, gitcmd.Step{Op: gitcmd.OpDeleteBranch, Branch: fullBranchName, Optional: true})
+	}
+
+	// 7. Push the result if options.push_after_finish is on
+	push, err := pushPlan(ctx, git, cfg, plan)
+	if err != nil {
+		return err
+	}
+
+	if dryRun {
+		return printPlan(append(plan, push...), results)
+	}
+	return executeFinish(ctx, git, opFeatureFinish, name, plan, push)
+}
+
+func runFeatureCheckout(cmd *cobra.Command, args []string) error {
+	report := beginReport("feature checkout", args)
+
+	if err := checkGitRepo(); err != nil {
+		return err
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+	defer cancel()
+
+	git := gitcmd.New()
+	cfg, err := loadConfig()
+	if err != nil {
+		return err
 	}
 
-	// 5. Execute merge
-	if err := git.Checkout(ctx, targetBranch); err != nil {
-		return fmt.Errorf("failed to checkout %s: %v", targetBranch, err)
+	// 1. Without a query, choose from the list
+	if len(args) == 0 {
+		branch, err := pickBranch(ctx, git, cfg, "Select feature branch to check out:", config.BranchFeature)
+		if err != nil {
+			return err
+		}
+		report.Branch = branch
+		return switchBranch(ctx, git, branch)
 	}
 
-	if err := git.Merge(ctx, fullBranchName, true); err != nil {
-		return fmt.Errorf("merge failed: %v\n💡 Resolve conflicts and run 'git merge --continue'", err)
+	// 2. Match the query against the feature branches
+	branches, err := collectFlowBranches(ctx, git, cfg, config.BranchFeature)
+	if err != nil {
+		return err
+	}
+	matches := matchBranches(branches, cfg.Prefixes.Feature, args[0])
+	switch len(matches) {
+	case 0:
+		return usageError(fmt.Errorf("no feature branch matches '%s'\n💡 Run 'gz-flow list feature' to see them", args[0]))
+	case 1:
+	default:
+		fmt.Fprintf(ui, "🔍 '%s' matches %d feature branches:\n", args[0], len(matches))
+		for _, b := range matches {
+			fmt.Fprintf(ui, "  %s (%s)\n", b.Name, pickDetails(b))
+		}
+		fmt.Fprintln(ui)
+		return usageError(fmt.Errorf("'%s' is ambiguous\n💡 Use more of the name, or run without a query to choose from a list", args[0]))
 	}
 
-	fmt.Printf("✅ Merged '%s' into '%s'\n", fullBranchName, targetBranch)
+	// 3. Switch to the only match
+	report.Branch = matches[0].Name
+	return switchBranch(ctx, git, matches[0].Name)
+}
 
-	// 6. Delete branch if requested
-	deleteBranch := cfg.Options.DeleteBranchAfterFinish && !keepBranch
-	if deleteBranch {
-		if err := git.DeleteBranch(ctx, fullBranchName); err != nil {
-			fmt.Printf("⚠️  Failed to delete branch: %v\n", err)
-		} else {
-			fmt.Printf("🗑️  Deleted branch '%s'\n", fullBranchName)
+// matchBranches returns the branches whose name without prefix matches query,
+// ignoring case: the exact name, else every name containing query, else every
+// name containing the letters of query in order
+func matchBranches(branches []flowBranch, prefix, query string) []flowBranch {
+	query = strings.ToLower(strings.TrimPrefix(query, prefix))
+
+	var containing, scattered []flowBranch
+	for _, b := range branches {
+		name := strings.ToLower(strings.TrimPrefix(b.Name, prefix))
+		switch {
+		case name == query:
+			return []flowBranch{b}
+		case strings.Contains(name, query):
+			containing = append(containing, b)
+		case inOrder(name, query):
+			scattered = append(scattered, b)
 		}
 	}
+	if len(containing) > 0 {
+		return containing
+	}
+	return scattered
+}
 
-	return nil
+// inOrder reports whether s contains the characters of sub in order
+func inOrder(s, sub string) bool {
+	for _, r := range sub {
+		i := strings.IndexRune(s, r)
+		if i < 0 {
+			return false
+		}
+		s = s[i+len(string(r)):]
+	}
+	return true
 }
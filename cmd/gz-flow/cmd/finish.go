@@ -0,0 +1,397 @@
+package cmd
+
+import (
+	"bufio"
+	"context"
+	"fmt"
+	"os"
+	"sort"
+	"time"
+
+	"github.com/spf13/cobra"
+
+	"github.com/gizzahub/gzh-cli-gitflow/internal/gitcmd"
+	"github.com/gizzahub/gzh-cli-gitflow/internal/state"
+	"github.com/gizzahub/gzh-cli-gitflow/pkg/config"
+)
+
+// Operation names recorded in the finish state; they match the CLI command path
+const (
+	opFeatureFinish = "feature finish"
+	opReleaseFinish = "release finish"
+	opHotfixFinish  = "hotfix finish"
+)
+
+var (
+	continueFinish bool
+	abortFinish    bool
+	rollbackFinish bool
+	noFetch        bool
+	allowConflicts bool
+	pickFinish     bool
+)
+
+// addFinishFlags registers the resume, rollback and pre-flight flags of a finish command
+func addFinishFlags(c *cobra.Command) {
+	c.Flags().BoolVar(&continueFinish, "continue", false, "Resume an interrupted finish after resolving conflicts")
+	c.Flags().BoolVar(&abortFinish, "abort", false, "Abort an interrupted finish and restore the original branches")
+	c.Flags().BoolVar(&rollbackFinish, "rollback", false, "Restore the original state without asking if a step fails")
+	c.Flags().BoolVar(&noFetch, "no-fetch", false, "Compare with the last fetched remote state instead of fetching (offline use)")
+	c.Flags().BoolVar(&allowConflicts, "allow-conflicts", false, "Skip the merge conflict prediction and resolve conflicts during the finish")
+	c.Flags().BoolVar(&pickFinish, "pick", false, "Choose the branch to finish from a list")
+}
+
+// resuming reports whether --continue or --abort was given
+func resuming() bool {
+	return continueFinish || abortFinish
+}
+
+// checkNoFinishInProgress fails if an interrupted finish is waiting to be continued or aborted
+func checkNoFinishInProgress(ctx context.Context, git *gitcmd.Executor) error {
+	gitDir, err := git.GitDir(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to locate .git directory: %v", err)
+	}
+
+	existing, err := state.Load(gitDir)
+	if err != nil {
+		return err
+	}
+	if existing != nil {
+		return fmt.Errorf("a %s of '%s' is already in progress\n💡 Run 'gz-flow %s --continue' or 'gz-flow %s --abort' first",
+			existing.Operation, existing.Name, existing.Operation, existing.Operation)
+	}
+	return nil
+}
+
+// pushPlan returns the steps that publish a finish when options.push_after_finish
+// is on: every merge target, every new tag and, if the flow branch is deleted
+// locally and was published, its remote copy
+func pushPlan(ctx context.Context, git *gitcmd.Executor, cfg *config.Config, plan gitcmd.Plan) (gitcmd.Plan, error) {
+	if !cfg.Options.PushAfterFinish {
+		return nil, nil
+	}
+	remote, err := resolveRemote(ctx, git, cfg)
+	if err != nil {
+		return nil, fmt.Errorf("%v\n💡 options.push_after_finish is on; disable it or add the remote", err)
+	}
+
+	var push, deletes gitcmd.Plan
+	pushed := map[string]bool{}
+	for _, step := range plan {
+		switch step.Op {
+		case gitcmd.OpMerge:
+			if !pushed[step.Into] {
+				pushed[step.Into] = true
+				push = append(push, gitcmd.Step{Op: gitcmd.OpPush, Branch: step.Into, Remote: remote})
+			}
+		case gitcmd.OpTag:
+			push = append(push, gitcmd.Step{Op: gitcmd.OpPushTag, Tag: step.Tag, Remote: remote})
+		case gitcmd.OpDeleteBranch:
+			if published, _ := git.RemoteBranchExists(ctx, remote, step.Branch); published {
+				deletes = append(deletes, gitcmd.Step{Op: gitcmd.OpDeleteRemoteBranch, Branch: step.Branch, Remote: remote})
+			}
+		}
+	}
+	return append(push, deletes...), nil
+}
+
+// executeFinish records the pre-operation state, applies all steps and then
+// pushes the result. Callers check checkNoFinishInProgress before validating
+// their arguments.
+func executeFinish(ctx context.Context, git *gitcmd.Executor, operation, name string, plan, push gitcmd.Plan) error {
+	gitDir, err := git.GitDir(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to locate .git directory: %v", err)
+	}
+
+	// Snapshot every branch the steps touch so a failure can be rolled back
+	var branches []string
+	for _, step := range plan {
+		branches = append(branches, step.Branch, step.Into)
+	}
+	tx, err := git.Begin(ctx, branches...)
+	if err != nil {
+		return err
+	}
+
+	st := &state.State{
+		Operation:   operation,
+		Name:        name,
+		Steps:       plan,
+		Push:        push,
+		Transaction: *tx,
+		StartedAt:   time.Now(),
+	}
+	if err := st.Save(gitDir); err != nil {
+		return err
+	}
+
+	return runSteps(ctx, git, gitDir, st)
+}
+
+// runSteps applies the remaining steps, saving progress after each one.
+// On failure the state is kept so the operation can be continued or aborted.
+func runSteps(ctx context.Context, git *gitcmd.Executor, gitDir string, st *state.State) error {
+	for st.Current() != nil {
+		step := *st.Current()
+		if err := git.Apply(ctx, step); err != nil {
+			if !step.Optional {
+				return interrupted(ctx, git, gitDir, st, step, err)
+			}
+			fmt.Fprintf(ui, "⚠️  '%s' failed: %v\n", step, err)
+		} else {
+			reportStep(step)
+			st.Track(step)
+			opReport.Completed = append(opReport.Completed, step.String())
+		}
+
+		st.Completed++
+		if err := st.Save(gitDir); err != nil {
+			return err
+		}
+	}
+
+	opReport.Tags = st.CreatedTags
+	if err := state.Clear(gitDir); err != nil {
+		return err
+	}
+	return pushFinish(ctx, git, st)
+}
+
+// pushFinish applies the push steps of a completed finish. A failure is a
+// partial success: the local branches and tags stay as they are.
+func pushFinish(ctx context.Context, git *gitcmd.Executor, st *state.State) error {
+	if len(st.Push) == 0 {
+		return nil
+	}
+
+	fmt.Fprintln(ui)
+	for i, step := range st.Push {
+		if err := git.Apply(ctx, step); err != nil {
+			if step.Optional {
+				fmt.Fprintf(ui, "⚠️  '%s' failed: %v\n", step, err)
+				continue
+			}
+			fmt.Fprintf(ui, "\n⚠️  %s '%s' completed locally, but pushing failed at: %s\n", capitalize(st.Operation), st.Name, step)
+			fmt.Fprintf(ui, "   Error: %v\n", err)
+			fmt.Fprintln(ui, "\n💡 Fix the problem above, then push the rest manually:")
+			for _, rest := range st.Push[i:] {
+				fmt.Fprintf(ui, "   %s\n", rest)
+			}
+
+			opReport.Status = statusPartial
+			opReport.Pending = st.Push[i:].Commands()
+			return withExitCode(exitPartial, fmt.Errorf("%s completed locally but the push failed: %v", st.Operation, err))
+		}
+		reportStep(step)
+		opReport.Completed = append(opReport.Completed, step.String())
+	}
+	return nil
+}
+
+// reportStep prints the outcome of a successful step
+func reportStep(step gitcmd.Step) {
+	switch step.Op {
+	case gitcmd.OpMerge:
+		fmt.Fprintf(ui, "✅ Merged '%s' into '%s'\n", step.Branch, step.Into)
+	case gitcmd.OpTag:
+		fmt.Fprintf(ui, "🏷️  Created tag '%s'\n", step.Tag)
+	case gitcmd.OpDeleteBranch:
+		fmt.Fprintf(ui, "🗑️  Deleted branch '%s'\n", step.Branch)
+	case gitcmd.OpPush:
+		fmt.Fprintf(ui, "⬆️  Pushed '%s' to '%s'\n", step.Branch, step.Remote)
+	case gitcmd.OpPushTag:
+		fmt.Fprintf(ui, "⬆️  Pushed tag '%s' to '%s'\n", step.Tag, step.Remote)
+	case gitcmd.OpDeleteRemoteBranch:
+		fmt.Fprintf(ui, "🗑️  Deleted remote branch '%s/%s'\n", step.Remote, step.Branch)
+	}
+}
+
+// interrupted explains where the operation stopped and offers to roll it back.
+// If the state is kept, it explains how to continue or abort later.
+func interrupted(ctx context.Context, git *gitcmd.Executor, gitDir string, st *state.State, step gitcmd.Step, cause error) error {
+	fmt.Fprintf(ui, "\n⏸️  %s '%s' stopped at: %s\n", capitalize(st.Operation), st.Name, step)
+	fmt.Fprintf(ui, "   Error: %v\n", cause)
+
+	if st.Completed > 0 {
+		fmt.Fprintln(ui, "\nCompleted steps:")
+		for _, done := range st.Steps[:st.Completed] {
+			fmt.Fprintf(ui, "  ✅ %s\n", done)
+		}
+	}
+
+	opReport.Tags = st.CreatedTags
+	opReport.Pending = st.Remaining().Commands()
+
+	merging, _ := git.IsMerging(ctx)
+	if merging {
+		files, _ := git.UnmergedFiles(ctx)
+		opReport.Conflicts = files
+		if len(files) > 0 {
+			fmt.Fprintln(ui, "\nConflicting files:")
+			for _, f := range files {
+				fmt.Fprintf(ui, "  ❌ %s\n", f)
+			}
+		}
+	}
+
+	// 1. Offer to restore the original state. Conflicts default to keeping
+	//    the state, since they are usually resolved and continued.
+	rollback := rollbackFinish
+	if !rollback && isInteractive() {
+		fmt.Fprintln(ui)
+		rollback = promptConfirm(bufio.NewReader(os.Stdin), "Restore the repository to its state before the finish?", !merging)
+	}
+	if rollback {
+		fmt.Fprintln(ui)
+		if err := rollbackOperation(ctx, git, gitDir, st); err != nil {
+			opReport.Status = statusInterrupted
+			return withExitCode(exitInterrupted, fmt.Errorf("rollback failed: %v\n💡 Run 'gz-flow %s --abort' to retry", err, st.Operation))
+		}
+		opReport.Status = statusRolledBack
+		opReport.Tags = nil
+		return withExitCode(exitRolledBack, fmt.Errorf("%s failed and was rolled back: %v", st.Operation, cause))
+	}
+
+	// 2. Keep the state for --continue or --abort
+	if merging {
+		fmt.Fprintln(ui, "\n💡 Resolve the conflicts and 'git add' the files, then run:")
+	} else {
+		fmt.Fprintln(ui, "\n💡 Fix the problem above, then run:")
+	}
+	fmt.Fprintf(ui, "   gz-flow %s --continue\n", st.Operation)
+	fmt.Fprintln(ui, "💡 Or restore the original state with:")
+	fmt.Fprintf(ui, "   gz-flow %s --abort\n", st.Operation)
+
+	opReport.Status = statusInterrupted
+	return withExitCode(exitInterrupted, fmt.Errorf("%s interrupted", st.Operation))
+}
+
+// resumeFinish handles --continue and --abort for the given operation
+func resumeFinish(ctx context.Context, git *gitcmd.Executor, operation string) error {
+	if continueFinish && abortFinish {
+		return usageError(fmt.Errorf("--continue and --abort cannot be used together"))
+	}
+
+	gitDir, err := git.GitDir(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to locate .git directory: %v", err)
+	}
+	st, err := state.Load(gitDir)
+	if err != nil {
+		return err
+	}
+	if st == nil {
+		return fmt.Errorf("no %s in progress", operation)
+	}
+	if st.Operation != operation {
+		return fmt.Errorf("a %s of '%s' is in progress, not a %s\n💡 Use 'gz-flow %s --continue' or 'gz-flow %s --abort'",
+			st.Operation, st.Name, operation, st.Operation, st.Operation)
+	}
+	opReport.Name = st.Name
+
+	if dryRun {
+		return printResumePlan(st)
+	}
+
+	if abortFinish {
+		if err := rollbackOperation(ctx, git, gitDir, st); err != nil {
+			return err
+		}
+		opReport.Status = statusAborted
+		fmt.Fprintf(ui, "✅ Aborted %s '%s'\n", st.Operation, st.Name)
+		return nil
+	}
+
+	// Conclude the merge the operation stopped at
+	if step := st.Current(); step != nil && step.Op == gitcmd.OpMerge {
+		merging, err := git.IsMerging(ctx)
+		if err != nil {
+			return err
+		}
+		if merging {
+			files, err := git.UnmergedFiles(ctx)
+			if err != nil {
+				return err
+			}
+			if len(files) > 0 {
+				fmt.Fprintln(ui, "❌ Unresolved conflicts remain:")
+				for _, f := range files {
+					fmt.Fprintf(ui, "  %s\n", f)
+				}
+				opReport.Status = statusInterrupted
+				opReport.Conflicts = files
+				opReport.Pending = st.Remaining().Commands()
+				return withExitCode(exitInterrupted, fmt.Errorf("resolve the conflicts and 'git add' the files before continuing"))
+			}
+			if err := git.CommitMerge(ctx); err != nil {
+				return fmt.Errorf("failed to conclude merge: %v", err)
+			}
+			reportStep(*step)
+			opReport.Completed = append(opReport.Completed, step.String())
+			st.Completed++
+		} else if merged, _ := git.IsMerged(ctx, step.Branch, step.Into); merged {
+			// The merge was concluded manually
+			reportStep(*step)
+			opReport.Completed = append(opReport.Completed, step.String())
+			st.Completed++
+		}
+		if err := st.Save(gitDir); err != nil {
+			return err
+		}
+	}
+
+	fmt.Fprintf(ui, "▶️  Resuming %s '%s'\n", st.Operation, st.Name)
+	return runSteps(ctx, git, gitDir, st)
+}
+
+// printResumePlan shows what --continue or --abort would do
+func printResumePlan(st *state.State) error {
+	fmt.Fprintf(ui, "📍 %s '%s' is in progress (%d of %d steps done)\n\n", capitalize(st.Operation), st.Name, st.Completed, len(st.Steps))
+
+	if abortFinish {
+		fmt.Fprintln(ui, "📋 Dry run: --abort would restore:")
+		branches := make([]string, 0, len(st.OriginalRefs))
+		for branch := range st.OriginalRefs {
+			branches = append(branches, branch)
+		}
+		sort.Strings(branches)
+		for _, branch := range branches {
+			fmt.Fprintf(ui, "  ↩️  '%s' to %s (if it moved)\n", branch, shortSHA(st.OriginalRefs[branch]))
+		}
+		for _, tag := range st.CreatedTags {
+			fmt.Fprintf(ui, "  ↩️  delete tag '%s'\n", tag)
+		}
+		fmt.Fprintln(ui)
+		fmt.Fprintln(ui, "💡 Nothing was changed; run again without --dry-run to apply")
+		return nil
+	}
+
+	return printPlan(st.Remaining(), nil)
+}
+
+// rollbackOperation restores every branch and tag to its pre-operation state
+// and clears the saved state
+func rollbackOperation(ctx context.Context, git *gitcmd.Executor, gitDir string, st *state.State) error {
+	restored, err := git.Rollback(ctx, &st.Transaction)
+	for _, branch := range restored {
+		fmt.Fprintf(ui, "↩️  Restored '%s' to %s\n", branch, shortSHA(st.OriginalRefs[branch]))
+	}
+	if err != nil {
+		return err
+	}
+	for _, tag := range st.CreatedTags {
+		fmt.Fprintf(ui, "↩️  Deleted tag '%s'\n", tag)
+	}
+
+	return state.Clear(gitDir)
+}
+
+// shortSHA abbreviates a commit SHA for display
+func shortSHA(sha string) string {
+	if len(sha) > 7 {
+		return sha[:7]
+	}
+	return sha
+}
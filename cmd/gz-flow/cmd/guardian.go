@@ -0,0 +1,59 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/gizzahub/gzh-cli-gitflow/internal/gitcmd"
+	"github.com/gizzahub/gzh-cli-gitflow/internal/state"
+	"github.com/gizzahub/gzh-cli-gitflow/pkg/config"
+)
+
+// checkNaming applies the guardian naming rule to a new branch name. A
+// violation fails with severity error, and is printed and logged with warn.
+func checkNaming(ctx context.Context, git *gitcmd.Executor, cfg *config.Config, operation, name, branch string) error {
+	if !cfg.Guardian.Enabled {
+		return nil
+	}
+	severity := cfg.Guardian.SeverityOf(config.RuleNaming)
+	if severity == config.SeverityOff {
+		return nil
+	}
+	err := cfg.Guardian.Naming.Validate(name)
+	if err == nil {
+		return nil
+	}
+	if severity == config.SeverityError {
+		return fmt.Errorf("guardian: %v", err)
+	}
+
+	fmt.Fprintf(ui, "⚠️  guardian: %v\n", err)
+	if !dryRun {
+		recordViolations(ctx, git, operation, state.Violation{Rule: config.RuleNaming, Branch: branch, Message: err.Error()})
+	}
+	fmt.Fprintln(ui)
+	return nil
+}
+
+// recordViolations appends guardian violations that were allowed to proceed to
+// the audit log. Failing to log only warns; it never stops the operation.
+func recordViolations(ctx context.Context, git *gitcmd.Executor, operation string, violations ...state.Violation) {
+	gitDir, err := git.GitDir(ctx)
+	if err != nil {
+		fmt.Fprintf(ui, "⚠️  Failed to record guardian warnings: %v\n", err)
+		return
+	}
+	user, _ := git.ConfigValue(ctx, "user.email")
+	now := time.Now().UTC()
+	for i := range violations {
+		violations[i].Time = now
+		violations[i].Operation = operation
+		violations[i].User = user
+	}
+	if err := state.AppendAudit(gitDir, violations...); err != nil {
+		fmt.Fprintf(ui, "⚠️  Failed to record guardian warnings: %v\n", err)
+		return
+	}
+	fmt.Fprintf(ui, "📝 Proceeding despite %d guardian warning(s); logged to %s\n", len(violations), state.AuditPath(gitDir))
+}
@@ -1,9 +1,16 @@
 package cmd
 
 import (
+	"context"
 	"fmt"
+	"strings"
+	"time"
 
 	"github.com/spf13/cobra"
+
+	"github.com/gizzahub/gzh-cli-gitflow/internal/gitcmd"
+	"github.com/gizzahub/gzh-cli-gitflow/internal/validator"
+	"github.com/gizzahub/gzh-cli-gitflow/pkg/config"
 )
 
 var hotfixCmd = &cobra.Command{
@@ -16,7 +23,10 @@ an undesired state of a live production version.
 
 Commands:
   start   - Start a new hotfix branch from master
-  finish  - Finish a hotfix branch (merge to master and develop, tag)`,
+  finish  - Finish a hotfix branch (merge to master and develop, tag)
+  publish - Push a hotfix branch to the remote
+  track   - Check out a hotfix branch from the remote
+  pull    - Update a hotfix branch with remote changes`,
 }
 
 var hotfixStartCmd = &cobra.Command{
@@ -32,7 +42,7 @@ Example:
 }
 
 var hotfixFinishCmd = &cobra.Command{
-	Use:   "finish <version>",
+	Use:   "finish [version]",
 	Short: "Finish a hotfix branch",
 	Long: `Finish a hotfix branch by merging it into master and develop.
 
@@ -42,9 +52,19 @@ This will:
   - Merge the hotfix branch into develop (or release if active)
   - Delete the hotfix branch
 
+The original position of every branch is recorded before the first
+step. If a step fails you are offered an automatic rollback (always
+done with --rollback) that resets the branches and deletes the tag.
+
+If a merge stops on a conflict, resolve it and run --continue to
+finish the remaining steps, or run --abort to restore the branches
+and delete the tag.
+
 Example:
-  gz-flow hotfix finish 1.0.1`,
-	Args: cobra.ExactArgs(1),
+  gz-flow hotfix finish 1.0.1
+  gz-flow hotfix finish --continue
+  gz-flow hotfix finish --abort`,
+	Args: cobra.MaximumNArgs(1),
 	RunE: runHotfixFinish,
 }
 
@@ -53,51 +73,230 @@ func init() {
 
 	hotfixCmd.AddCommand(hotfixStartCmd)
 	hotfixCmd.AddCommand(hotfixFinishCmd)
+	hotfixCmd.AddCommand(newPublishCmd(config.BranchHotfix))
+	hotfixCmd.AddCommand(newTrackCmd(config.BranchHotfix))
+	hotfixCmd.AddCommand(newPullCmd(config.BranchHotfix))
 
 	hotfixFinishCmd.Flags().StringVarP(&tagMessage, "message", "m", "", "Tag message")
 	hotfixFinishCmd.Flags().BoolVar(&noTag, "no-tag", false, "Don't create a tag")
 	hotfixFinishCmd.Flags().BoolVarP(&keepBranch, "keep", "k", false, "Keep the hotfix branch")
+	addFinishFlags(hotfixFinishCmd)
 }
 
 func runHotfixStart(cmd *cobra.Command, args []string) error {
+	report := beginReport("hotfix start", args)
+
 	if err := checkGitRepo(); err != nil {
 		return err
 	}
 
+	ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+	defer cancel()
+
+	git := gitcmd.New()
 	version := args[0]
 
-	// TODO: Implement hotfix start logic
-	// 1. Validate version format
-	// 2. Check out master branch
-	// 3. Create hotfix branch
+	// 1. Validate version format (strict semver)
+	if err := validator.ValidateVersion(version); err != nil {
+		return usageError(fmt.Errorf("invalid version: %v\n💡 Use semver format: 1.0.1", err))
+	}
+
+	// 2. Load config
+	cfg, err := loadConfig()
+	if err != nil {
+		return err
+	}
+
+	masterBranch := cfg.Branches.Master
+
+	// 3. Check if hotfix branch or tag already exists
+	hotfixBranch := cfg.Prefixes.Hotfix + version
+	exists, _ := git.BranchExists(ctx, hotfixBranch)
+	if exists {
+		return fmt.Errorf("hotfix branch '%s' already exists", hotfixBranch)
+	}
+
+	tagName := fmt.Sprintf(cfg.Options.TagFormat, version)
+	tagExists, _ := git.TagExists(ctx, tagName)
+	if tagExists {
+		return fmt.Errorf("tag '%s' already exists\n💡 Version %s has already been released; use a higher version", tagName, version)
+	}
+
+	// 4. Emergency context: allow uncommitted changes, but warn
+	clean, err := git.IsClean(ctx)
+	if err == nil && !clean {
+		fmt.Fprintln(ui, "⚠️  You have uncommitted changes; they will be carried onto the hotfix branch")
+	}
+
+	// 5. Context hint: warn if not on master
+	currentBranch, _ := git.CurrentBranch(ctx)
+	if currentBranch != masterBranch {
+		fmt.Fprintf(ui, "⚠️  You're on '%s', not '%s'\n", currentBranch, masterBranch)
+		fmt.Fprintf(ui, "💡 Will checkout '%s' first\n\n", masterBranch)
+	}
+
+	// 6. Create hotfix branch from master
+	plan := gitcmd.Plan{
+		{Op: gitcmd.OpCheckout, Branch: masterBranch},
+		{Op: gitcmd.OpCreateBranch, Branch: hotfixBranch},
+	}
+	if dryRun {
+		return printPlan(plan, nil)
+	}
+	if err := git.Execute(ctx, plan); err != nil {
+		return err
+	}
+	report.Branch = hotfixBranch
+	report.Completed = plan.Commands()
 
-	fmt.Printf("Started hotfix branch 'hotfix/%s'\n", version)
-	fmt.Printf("Switched to branch 'hotfix/%s'\n", version)
+	fmt.Fprintf(ui, "✅ Started hotfix branch '%s'\n", hotfixBranch)
+	fmt.Fprintf(ui, "📍 Switched to branch '%s'\n", hotfixBranch)
 
 	return nil
 }
 
 func runHotfixFinish(cmd *cobra.Command, args []string) error {
+	beginReport(opHotfixFinish, args)
+
 	if err := checkGitRepo(); err != nil {
 		return err
 	}
 
+	ctx, cancel := context.WithTimeout(context.Background(), 60*time.Second)
+	defer cancel()
+
+	git := gitcmd.New()
+	if resuming() {
+		return resumeFinish(ctx, git, opHotfixFinish)
+	}
+	if err := checkNoFinishInProgress(ctx, git); err != nil {
+		return err
+	}
+	if pickFinish {
+		var err error
+		if args, err = pickArgs(ctx, git, config.BranchHotfix, args); err != nil {
+			return err
+		}
+	}
+	if len(args) == 0 {
+		return usageError(fmt.Errorf("version is required\nUsage: gz-flow hotfix finish <version>\n💡 Or choose the branch with --pick"))
+	}
 	version := args[0]
 
-	// TODO: Implement hotfix finish logic
-	// 1. Merge hotfix to master
-	// 2. Create tag
-	// 3. Merge hotfix to develop (or active release branch)
-	// 4. Delete hotfix branch
+	// 1. Validate version
+	if err := validator.ValidateVersion(version); err != nil {
+		return usageError(fmt.Errorf("invalid version: %v", err))
+	}
+
+	// 2. Load config
+	cfg, err := loadConfig()
+	if err != nil {
+		return err
+	}
+
+	hotfixBranch := cfg.Prefixes.Hotfix + version
+	masterBranch := cfg.Branches.Master
 
-	fmt.Printf("Merged hotfix/%s into master\n", version)
+	// 3. Verify hotfix branch exists
+	exists, _ := git.BranchExists(ctx, hotfixBranch)
+	if !exists {
+		return fmt.Errorf("hotfix branch '%s' does not exist", hotfixBranch)
+	}
+
+	// Check the tag before touching any branch
+	tagName := fmt.Sprintf(cfg.Options.TagFormat, version)
 	if !noTag {
-		fmt.Printf("Created tag 'v%s'\n", version)
+		tagExists, _ := git.TagExists(ctx, tagName)
+		if tagExists {
+			return fmt.Errorf("tag '%s' already exists\n💡 Use different version or delete existing tag", tagName)
+		}
+	}
+
+	// 4. Merge back into the active release branch if there is one, otherwise develop
+	backBranch, err := hotfixMergeBackTarget(ctx, git, cfg)
+	if err != nil {
+		return err
+	}
+
+	// 5. Pre-flight checks; without develop there is nothing to merge back into
+	targets := []string{masterBranch}
+	if backBranch != "" {
+		targets = append(targets, backBranch)
 	}
-	fmt.Printf("Merged hotfix/%s into develop\n", version)
-	if !keepBranch {
-		fmt.Printf("Deleted branch hotfix/%s\n", version)
+	results := runPreflight(ctx, git, cfg, opHotfixFinish, hotfixBranch, targets...)
+	if results.HasErrors() && !dryRun {
+		return withExitCode(exitPreflight, fmt.Errorf("pre-flight checks failed"))
 	}
+	// Custom checks may outlast the timeout; restart it for the steps
+	ctx, cancel = context.WithTimeout(context.Background(), 60*time.Second)
+	defer cancel()
 
-	return nil
+	// 6. Merge to master (--no-ff) and tag it
+	plan := gitcmd.Plan{
+		{Op: gitcmd.OpCheckout, Branch: masterBranch},
+		{Op: gitcmd.OpMerge, Branch: hotfixBranch, Into: masterBranch, NoFF: true},
+	}
+	if !noTag {
+		message := tagMessage
+		if message == "" {
+			message = fmt.Sprintf("Hotfix version %s", version)
+		}
+		plan = append(plan, gitcmd.Step{Op: gitcmd.OpTag, Tag: tagName, Message: message})
+	}
+
+	// 7. Merge to release or develop
+	if backBranch == "" {
+		fmt.Fprintf(ui, "⚠️  Develop branch '%s' does not exist\n", cfg.Branches.Develop)
+		fmt.Fprintf(ui, "💡 Skipping merge to develop\n")
+	} else {
+		plan = append(plan,
+			gitcmd.Step{Op: gitcmd.OpCheckout, Branch: backBranch},
+			gitcmd.Step{Op: gitcmd.OpMerge, Branch: hotfixBranch, Into: backBranch, NoFF: true},
+		)
+	}
+
+	// 8. Delete hotfix branch
+	if cfg.Options.DeleteBranchAfterFinish && !keepBranch {
+		plan = append(plan, gitcmd.Step{Op: gitcmd.OpDeleteBranch, Branch: hotfixBranch, Optional: true})
+	}
+
+	// 9. Push the result if options.push_after_finish is on
+	push, err := pushPlan(ctx, git, cfg, plan)
+	if err != nil {
+		return err
+	}
+
+	if dryRun {
+		return printPlan(append(plan, push...), results)
+	}
+	return executeFinish(ctx, git, opHotfixFinish, version, plan, push)
+}
+
+// hotfixMergeBackTarget returns the branch a finished hotfix is merged back into:
+// the active release branch if exactly one exists, otherwise develop.
+// It returns "" if neither exists.
+func hotfixMergeBackTarget(ctx context.Context, git *gitcmd.Executor, cfg *config.Config) (string, error) {
+	releases, err := git.ListBranches(ctx, cfg.Prefixes.Release)
+	if err != nil {
+		return "", fmt.Errorf("failed to list release branches: %v", err)
+	}
+
+	switch len(releases) {
+	case 0:
+	case 1:
+		fmt.Fprintf(ui, "📍 Active release branch '%s' will receive the hotfix\n\n", releases[0])
+		return releases[0], nil
+	default:
+		fmt.Fprintf(ui, "⚠️  Multiple release branches are active (%s)\n", strings.Join(releases, ", "))
+		fmt.Fprintf(ui, "💡 Merging into '%s' instead; merge the hotfix into the release you ship next\n\n", cfg.Branches.Develop)
+	}
+
+	developExists, err := git.BranchExists(ctx, cfg.Branches.Develop)
+	if err != nil {
+		return "", fmt.Errorf("failed to check branch '%s': %v", cfg.Branches.Develop, err)
+	}
+	if !developExists {
+		return "", nil
+	}
+	return cfg.Branches.Develop, nil
 }
@@ -1,9 +1,16 @@
 package cmd
 
 import (
+	"bufio"
+	"context"
 	"fmt"
+	"os"
+	"time"
 
 	"github.com/spf13/cobra"
+
+	"github.com/gizzahub/gzh-cli-gitflow/internal/gitcmd"
+	"github.com/gizzahub/gzh-cli-gitflow/pkg/config"
 )
 
 var initCmd = &cobra.Command{
@@ -18,7 +25,8 @@ This will:
 
 Example:
   gz-flow init
-  gz-flow init --defaults    # Use all defaults without prompting`,
+  gz-flow init --defaults    # Use all defaults without prompting
+  gz-flow init --force       # Re-initialize and overwrite .gzflow.yaml`,
 	RunE: runInit,
 }
 
@@ -39,18 +47,137 @@ func runInit(cmd *cobra.Command, args []string) error {
 		return err
 	}
 
-	// TODO: Implement initialization logic
-	// 1. Detect master/main branch
-	// 2. Create develop branch if not exists
-	// 3. Save configuration
+	ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+	defer cancel()
+
+	git := gitcmd.New()
+
+	// 1. Refuse to overwrite an existing configuration
+	if _, err := os.Stat(config.LocalFileName); err == nil && !force {
+		return fmt.Errorf("git-flow is already initialized (%s exists)\n💡 Use 'gz-flow init --force' to re-initialize", config.LocalFileName)
+	}
+
+	// 2. Detect master/main branch (other settings start from global/env config)
+	cfg, err := loadConfig()
+	if err != nil {
+		return err
+	}
+	master, err := detectMasterBranch(ctx, git, cfg.Branches)
+	if err != nil {
+		return err
+	}
+	cfg.Branches.Master = master
+
+	// 3. Ask for branch names unless defaults were requested
+	if !useDefaults && isInteractive() {
+		reader := bufio.NewReader(os.Stdin)
+		cfg.Branches.Master = promptValue(reader, "Branch name for production releases", cfg.Branches.Master)
+		cfg.Branches.Develop = promptValue(reader, "Branch name for next release development", cfg.Branches.Develop)
+		cfg.Prefixes.Feature = promptValue(reader, "Feature branch prefix", cfg.Prefixes.Feature)
+		cfg.Prefixes.Release = promptValue(reader, "Release branch prefix", cfg.Prefixes.Release)
+		cfg.Prefixes.Hotfix = promptValue(reader, "Hotfix branch prefix", cfg.Prefixes.Hotfix)
+		fmt.Fprintln(ui)
+	}
+
+	if cfg.Branches.Master == cfg.Branches.Develop {
+		return fmt.Errorf("master and develop branches must differ (both are '%s')", cfg.Branches.Master)
+	}
+
+	// 4. Master branch must exist (requires at least one commit)
+	masterExists, err := git.BranchExists(ctx, cfg.Branches.Master)
+	if err != nil {
+		return fmt.Errorf("failed to check branch '%s': %v", cfg.Branches.Master, err)
+	}
+	if !masterExists {
+		return fmt.Errorf("branch '%s' does not exist\n💡 Create an initial commit first, or choose an existing branch", cfg.Branches.Master)
+	}
+
+	// 5. Create develop branch if it doesn't exist
+	developExists, err := git.BranchExists(ctx, cfg.Branches.Develop)
+	if err != nil {
+		return fmt.Errorf("failed to check branch '%s': %v", cfg.Branches.Develop, err)
+	}
+	if !developExists {
+		if err := git.CreateBranchFrom(ctx, cfg.Branches.Develop, cfg.Branches.Master); err != nil {
+			return fmt.Errorf("failed to create %s: %v", cfg.Branches.Develop, err)
+		}
+		fmt.Fprintf(ui, "🌱 Created branch '%s' from '%s'\n", cfg.Branches.Develop, cfg.Branches.Master)
+	}
+
+	// 6. Save configuration (only what init decides, so global options still apply)
+	if force {
+		if err := os.Remove(config.LocalFileName); err != nil && !os.IsNotExist(err) {
+			return fmt.Errorf("failed to remove old configuration: %v", err)
+		}
+	}
+	if err := cfg.SaveKeys(config.LocalFileName, initKeys...); err != nil {
+		return err
+	}
+
+	report := &configReport{Config: []configEntry{}}
+	for _, key := range initKeys {
+		value, _ := cfg.Get(key)
+		report.Config = append(report.Config, configEntry{Key: key, Value: value, File: config.LocalFileName})
+	}
+	result = report
 
-	fmt.Println("Git-flow initialized successfully!")
-	fmt.Println("")
-	fmt.Println("Summary of branches:")
-	fmt.Println("  - master: master")
-	fmt.Println("  - develop: develop")
-	fmt.Println("")
-	fmt.Println("Configuration saved to .gzflow.yaml")
+	fmt.Fprintln(ui, "✅ Git-flow initialized successfully!")
+	fmt.Fprintln(ui, "")
+	fmt.Fprintln(ui, "Summary of branches:")
+	fmt.Fprintf(ui, "  - master:  %s\n", cfg.Branches.Master)
+	fmt.Fprintf(ui, "  - develop: %s\n", cfg.Branches.Develop)
+	fmt.Fprintln(ui, "")
+	fmt.Fprintf(ui, "Configuration saved to %s\n", config.LocalFileName)
+	fmt.Fprintf(ui, "💡 Commit %s to share the configuration with your team\n", config.LocalFileName)
 
 	return nil
 }
+
+// initKeys are the settings init writes to the project configuration
+var initKeys = []string{
+	"branches.master", "branches.develop",
+	"prefixes.feature", "prefixes.release", "prefixes.hotfix",
+}
+
+// detectMasterBranch picks the production branch for this repository.
+// Order: origin/HEAD, then existing main/master, then the branch HEAD points at.
+func detectMasterBranch(ctx context.Context, git *gitcmd.Executor, defaults config.BranchConfig) (string, error) {
+	if remoteHead, _ := git.RemoteDefaultBranch(ctx, "origin"); remoteHead != "" {
+		if exists, _ := git.BranchExists(ctx, remoteHead); exists {
+			return remoteHead, nil
+		}
+	}
+
+	current, err := git.CurrentBranch(ctx)
+	if err != nil {
+		return "", fmt.Errorf("failed to get current branch: %v", err)
+	}
+
+	var candidates []string
+	for _, name := range []string{"main", "master"} {
+		exists, err := git.BranchExists(ctx, name)
+		if err != nil {
+			return "", fmt.Errorf("failed to check branch '%s': %v", name, err)
+		}
+		if exists {
+			candidates = append(candidates, name)
+		}
+	}
+
+	switch len(candidates) {
+	case 1:
+		return candidates[0], nil
+	case 2:
+		// Both exist: prefer the one that is checked out
+		if current == "main" {
+			return "main", nil
+		}
+		return "master", nil
+	}
+
+	// Neither exists: use HEAD (e.g. a fresh repository with a custom default branch)
+	if current != "" && current != defaults.Develop {
+		return current, nil
+	}
+	return defaults.Master, nil
+}
@@ -1,9 +1,17 @@
 package cmd
 
 import (
+	"context"
 	"fmt"
+	"sort"
+	"strings"
+	"text/tabwriter"
+	"time"
 
 	"github.com/spf13/cobra"
+
+	"github.com/gizzahub/gzh-cli-gitflow/internal/gitcmd"
+	"github.com/gizzahub/gzh-cli-gitflow/pkg/config"
 )
 
 var listCmd = &cobra.Command{
@@ -14,15 +22,29 @@ var listCmd = &cobra.Command{
 If type is specified, only list branches of that type.
 Valid types: feature, release, hotfix
 
+For each branch, shows the last commit date and author, the number of
+commits ahead of its base branch, and whether it is already merged.
+
 Example:
-  gz-flow list           # List all flow branches
-  gz-flow list feature   # List only feature branches`,
+  gz-flow list               # List all flow branches
+  gz-flow list feature       # List only feature branches
+  gz-flow list --sort age    # Most recently active first
+  gz-flow list --sort ahead  # Most commits ahead first`,
 	Args: cobra.MaximumNArgs(1),
 	RunE: runList,
 }
 
+var listSort string
+
+// listReport is the structured output of list
+type listReport struct {
+	Branches []flowBranch `json:"branches" yaml:"branches"`
+}
+
 func init() {
 	rootCmd.AddCommand(listCmd)
+
+	listCmd.Flags().StringVar(&listSort, "sort", "name", "Sort order: name, age, ahead")
 }
 
 func runList(cmd *cobra.Command, args []string) error {
@@ -30,31 +52,82 @@ func runList(cmd *cobra.Command, args []string) error {
 		return err
 	}
 
-	var branchType string
-	if len(args) > 0 {
-		branchType = args[0]
+	ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+	defer cancel()
+
+	git := gitcmd.New()
+	cfg, err := loadConfig()
+	if err != nil {
+		return err
 	}
 
-	// TODO: Implement list logic
-	// 1. Get all branches
-	// 2. Filter by type if specified
-	// 3. Display with metadata
+	less, err := branchSorter(listSort)
+	if err != nil {
+		return usageError(err)
+	}
 
-	if branchType != "" {
-		fmt.Printf("Active %s branches:\n", branchType)
+	// 1. Filter by type if specified
+	types := config.FlowTypes
+	if len(args) > 0 {
+		t, ok := config.ParseBranchType(args[0])
+		if !ok {
+			return usageError(fmt.Errorf("invalid branch type '%s'\n💡 Valid types: feature, release, hotfix", args[0]))
+		}
+		types = []config.BranchType{t}
+		fmt.Fprintf(ui, "Active %s branches:\n", t)
 	} else {
-		fmt.Println("Active git-flow branches:")
+		fmt.Fprintln(ui, "Active git-flow branches:")
 	}
 
-	fmt.Println("")
-	fmt.Println("Feature branches:")
-	fmt.Println("  (none)")
-	fmt.Println("")
-	fmt.Println("Release branches:")
-	fmt.Println("  (none)")
-	fmt.Println("")
-	fmt.Println("Hotfix branches:")
-	fmt.Println("  (none)")
+	// 2. Display with metadata
+	report := &listReport{Branches: []flowBranch{}}
+	result = report
+	for _, t := range types {
+		branches, err := collectFlowBranches(ctx, git, cfg, t)
+		if err != nil {
+			return err
+		}
+		sort.SliceStable(branches, func(i, j int) bool { return less(branches[i], branches[j]) })
+		report.Branches = append(report.Branches, branches...)
+
+		fmt.Fprintln(ui, "")
+		fmt.Fprintf(ui, "%s branches:\n", capitalize(string(t)))
+		if len(branches) == 0 {
+			fmt.Fprintln(ui, "  (none)")
+			continue
+		}
+
+		w := tabwriter.NewWriter(ui, 0, 0, 2, ' ', 0)
+		for _, b := range branches {
+			state := fmt.Sprintf("%d ahead of %s", b.Ahead, b.Base)
+			if b.Merged {
+				state += ", merged"
+			}
+			fmt.Fprintf(w, "  %s\t%s\t%s\t%s\n", b.Name, humanizeAge(b.Age()), b.Author, state)
+		}
+		w.Flush()
+	}
 
 	return nil
 }
+
+// branchSorter returns the comparison used by --sort
+func branchSorter(key string) (func(a, b flowBranch) bool, error) {
+	switch key {
+	case "name":
+		return func(a, b flowBranch) bool { return a.Name < b.Name }, nil
+	case "age":
+		return func(a, b flowBranch) bool { return a.LastCommit.After(b.LastCommit) }, nil
+	case "ahead":
+		return func(a, b flowBranch) bool { return a.Ahead > b.Ahead }, nil
+	}
+	return nil, fmt.Errorf("invalid sort key '%s' (valid: name, age, ahead)", key)
+}
+
+// capitalize upper-cases the first letter of s
+func capitalize(s string) string {
+	if s == "" {
+		return s
+	}
+	return strings.ToUpper(s[:1]) + s[1:]
+}
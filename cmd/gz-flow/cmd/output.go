@@ -0,0 +1,201 @@
+package cmd
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+
+	"github.com/spf13/cobra"
+	"gopkg.in/yaml.v3"
+
+	"github.com/gizzahub/gzh-cli-gitflow/internal/preflight"
+)
+
+// Output formats accepted by --output
+const (
+	outputText = "text"
+	outputJSON = "json"
+	outputYAML = "yaml"
+)
+
+// Exit codes, one per failure class. They are part of the CLI contract
+// (see README) so scripts can react without parsing messages.
+const (
+	exitOK          = 0
+	exitFailure     = 1 // unclassified failure
+	exitUsage       = 2 // invalid arguments, flags, names or versions
+	exitNotRepo     = 3 // not inside a git repository
+	exitConfig      = 4 // configuration cannot be loaded
+	exitPreflight   = 5 // pre-flight checks failed; nothing was changed
+	exitInterrupted = 6 // operation stopped part-way; run --continue or --abort
+	exitRolledBack  = 7 // operation failed and the repository was restored
+	exitPartial     = 8 // finish completed locally but pushing the result failed
+	exitAudit       = 9 // audit found more issues than --max-issues allows
+)
+
+// Operation statuses reported in the structured output of start/finish
+const (
+	statusSuccess     = "success"
+	statusDryRun      = "dry_run"
+	statusFailed      = "failed"
+	statusInterrupted = "interrupted"
+	statusRolledBack  = "rolled_back"
+	statusAborted     = "aborted"
+	statusPartial     = "partial" // completed locally, push failed
+)
+
+var (
+	outputFormat string
+
+	// ui receives the human-readable text. With --output json|yaml it goes
+	// to stderr so that stdout carries only the structured result.
+	ui io.Writer = os.Stdout
+
+	// result is the structured result of the command, written by Execute
+	result any
+
+	// opReport is the result of the running start/finish command
+	opReport *operationReport
+)
+
+// codedError attaches an exit code to an error
+type codedError struct {
+	code int
+	err  error
+}
+
+func (e *codedError) Error() string { return e.err.Error() }
+func (e *codedError) Unwrap() error { return e.err }
+
+// withExitCode classifies err with an exit code
+func withExitCode(code int, err error) error {
+	return &codedError{code: code, err: err}
+}
+
+// usageError classifies err as a usage error
+func usageError(err error) error {
+	return withExitCode(exitUsage, err)
+}
+
+// ExitCode returns the process exit code for an error returned by Execute
+func ExitCode(err error) int {
+	if err == nil {
+		return exitOK
+	}
+	var e *codedError
+	if errors.As(err, &e) {
+		return e.code
+	}
+	return exitFailure
+}
+
+// operationReport is the structured result of a start or finish command
+type operationReport struct {
+	Operation string            `json:"operation" yaml:"operation"`
+	Name      string            `json:"name" yaml:"name"`
+	Status    string            `json:"status" yaml:"status"`
+	Branch    string            `json:"branch,omitempty" yaml:"branch,omitempty"` // branch created by start
+	Tags      []string          `json:"tags,omitempty" yaml:"tags,omitempty"`     // tags created by finish
+	Preflight preflight.Results `json:"preflight,omitempty" yaml:"preflight,omitempty"`
+	Completed []string          `json:"completed,omitempty" yaml:"completed,omitempty"` // git commands that ran
+	Pending   []string          `json:"pending,omitempty" yaml:"pending,omitempty"`     // git commands not run
+	Conflicts []string          `json:"conflicts,omitempty" yaml:"conflicts,omitempty"`
+	Overlaps  []overlap         `json:"overlaps,omitempty" yaml:"overlaps,omitempty"` // files other features change too
+	Error     string            `json:"error,omitempty" yaml:"error,omitempty"`
+	ExitCode  int               `json:"exit_code" yaml:"exit_code"`
+}
+
+// errorRecorder is a command result that reports the command's failure itself
+type errorRecorder interface {
+	setError(err error)
+}
+
+// errorReport is written when a command without its own result fails
+type errorReport struct {
+	Error    string `json:"error" yaml:"error"`
+	ExitCode int    `json:"exit_code" yaml:"exit_code"`
+}
+
+// beginReport starts the structured result of a start/finish command
+func beginReport(operation string, args []string) *operationReport {
+	opReport = &operationReport{Operation: operation}
+	if len(args) > 0 {
+		opReport.Name = args[0]
+	}
+	result = opReport
+	return opReport
+}
+
+// structuredOutput reports whether --output selects a machine-readable format
+func structuredOutput() bool {
+	return outputFormat == outputJSON || outputFormat == outputYAML
+}
+
+// setupOutput validates --output and redirects the human-readable text
+func setupOutput(cmd *cobra.Command, args []string) error {
+	switch outputFormat {
+	case outputText:
+	case outputJSON, outputYAML:
+		ui = os.Stderr
+	default:
+		return usageError(fmt.Errorf("invalid output format '%s'\n💡 Valid formats: text, json, yaml", outputFormat))
+	}
+	return nil
+}
+
+// writeResult prints the structured result of the command, or err if the
+// command has no result of its own
+func writeResult(w io.Writer, err error) error {
+	v := result
+	switch {
+	case opReport != nil:
+		if err != nil {
+			opReport.Error = err.Error()
+			opReport.ExitCode = ExitCode(err)
+			if opReport.Status == "" || opReport.Status == statusSuccess || opReport.Status == statusDryRun {
+				opReport.Status = statusFailed
+			}
+		} else if opReport.Status == "" {
+			opReport.Status = statusSuccess
+		}
+	case err != nil:
+		if r, ok := v.(errorRecorder); ok {
+			r.setError(err)
+		} else {
+			v = errorReport{Error: err.Error(), ExitCode: ExitCode(err)}
+		}
+	case v == nil:
+		return nil
+	}
+
+	if outputFormat == outputYAML {
+		enc := yaml.NewEncoder(w)
+		enc.SetIndent(2)
+		if err := enc.Encode(v); err != nil {
+			return err
+		}
+		return enc.Close()
+	}
+	enc := json.NewEncoder(w)
+	enc.SetIndent("", "  ")
+	enc.SetEscapeHTML(false) // hints contain shell commands such as '&&'
+
+	return enc.Encode(v)
+}
+
+// classifyArgs marks argument validation errors of every command as usage errors
+func classifyArgs(c *cobra.Command) {
+	if validate := c.Args; validate != nil {
+		c.Args = func(cmd *cobra.Command, args []string) error {
+			if err := validate(cmd, args); err != nil {
+				return usageError(err)
+			}
+			return nil
+		}
+	}
+	for _, sub := range c.Commands() {
+		classifyArgs(sub)
+	}
+}
@@ -0,0 +1,169 @@
+package cmd
+
+import (
+	"bufio"
+	"context"
+	"fmt"
+	"os"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"golang.org/x/term"
+
+	"github.com/gizzahub/gzh-cli-gitflow/internal/gitcmd"
+	"github.com/gizzahub/gzh-cli-gitflow/pkg/config"
+)
+
+// pickStaleDays is the age after which the selector marks a branch stale,
+// the same as the default of audit --stale-days
+const pickStaleDays = 30
+
+// pickArgs replaces the missing name argument of a finish command with the
+// branch chosen in the selector
+func pickArgs(ctx context.Context, git *gitcmd.Executor, t config.BranchType, args []string) ([]string, error) {
+	if len(args) > 0 {
+		return nil, usageError(fmt.Errorf("--pick cannot be combined with a %s name", t))
+	}
+	cfg, err := loadConfig()
+	if err != nil {
+		return nil, err
+	}
+
+	branch, err := pickBranch(ctx, git, cfg, fmt.Sprintf("Select %s branch to finish:", t), t)
+	if err != nil {
+		return nil, err
+	}
+	name := strings.TrimPrefix(branch, cfg.Prefix(t))
+	opReport.Name = name
+	return []string{name}, nil
+}
+
+// pickBranch lets the user choose one of the flow branches of the given
+// types, most recently active first. It shows an arrow-key menu in a
+// terminal and a numbered list otherwise.
+func pickBranch(ctx context.Context, git *gitcmd.Executor, cfg *config.Config, question string, types ...config.BranchType) (string, error) {
+	var branches []flowBranch
+	for _, t := range types {
+		found, err := collectFlowBranches(ctx, git, cfg, t)
+		if err != nil {
+			return "", err
+		}
+		branches = append(branches, found...)
+	}
+	if len(branches) == 0 {
+		kinds := make([]string, len(types))
+		for i, t := range types {
+			kinds[i] = string(t)
+		}
+		return "", fmt.Errorf("no %s branches to choose from", strings.Join(kinds, ", "))
+	}
+	sort.SliceStable(branches, func(i, j int) bool { return branches[i].LastCommit.After(branches[j].LastCommit) })
+
+	labels := make([]string, len(branches))
+	for i, b := range branches {
+		labels[i] = fmt.Sprintf("%s (%s)", b.Name, pickDetails(b))
+	}
+
+	var choice int
+	var err error
+	if isInteractive() && uiIsTerminal() {
+		choice, err = selectMenu(question, labels)
+	} else {
+		choice, err = selectNumbered(bufio.NewReader(os.Stdin), question, labels)
+	}
+	if err != nil {
+		return "", err
+	}
+	fmt.Fprintf(ui, "📍 Selected %s\n\n", branches[choice].Name)
+	return branches[choice].Name, nil
+}
+
+// pickDetails describes a branch in the selector
+func pickDetails(b flowBranch) string {
+	details := []string{humanizeAge(b.Age()), fmt.Sprintf("%s ahead", plural(b.Ahead, "commit"))}
+	if b.Age() > pickStaleDays*24*time.Hour {
+		details = append(details, "stale")
+	}
+	return strings.Join(details, ", ")
+}
+
+// uiIsTerminal reports whether the human-readable text goes to a terminal
+func uiIsTerminal() bool {
+	f, ok := ui.(*os.File)
+	return ok && term.IsTerminal(int(f.Fd()))
+}
+
+// selectMenu shows labels as a menu navigated with the arrow keys (or j/k)
+// and returns the index chosen with enter. Esc, q and ctrl-c cancel.
+func selectMenu(question string, labels []string) (int, error) {
+	fd := int(os.Stdin.Fd())
+	saved, err := term.MakeRaw(fd)
+	if err != nil {
+		return 0, fmt.Errorf("failed to read the terminal: %v", err)
+	}
+	defer term.Restore(fd, saved)
+
+	// Raw mode turns off output processing, so lines end with \r\n
+	cursor := 0
+	draw := func() {
+		for i, label := range labels {
+			marker := " "
+			if i == cursor {
+				marker = "❯"
+			}
+			fmt.Fprintf(ui, "\x1b[2K  %s %s\r\n", marker, label)
+		}
+	}
+	fmt.Fprintf(ui, "? %s\r\n", question)
+	draw()
+
+	key := make([]byte, 3)
+	for {
+		n, err := os.Stdin.Read(key)
+		if err != nil {
+			return 0, fmt.Errorf("failed to read the terminal: %v", err)
+		}
+		switch string(key[:n]) {
+		case "\x1b[A", "k":
+			cursor = (cursor + len(labels) - 1) % len(labels)
+		case "\x1b[B", "j":
+			cursor = (cursor + 1) % len(labels)
+		case "\r", "\n":
+			return cursor, nil
+		case "\x1b", "q", "\x03":
+			return 0, fmt.Errorf("no branch selected")
+		default:
+			continue
+		}
+		// Move back to the first entry and redraw
+		fmt.Fprintf(ui, "\x1b[%dA", len(labels))
+		draw()
+	}
+}
+
+// selectNumbered lists labels with numbers and reads the chosen number,
+// asking again until the answer is valid
+func selectNumbered(reader *bufio.Reader, question string, labels []string) (int, error) {
+	fmt.Fprintf(ui, "? %s\n", question)
+	for i, label := range labels {
+		fmt.Fprintf(ui, "  %d) %s\n", i+1, label)
+	}
+	for {
+		fmt.Fprintf(ui, "Enter a number [1-%d]: ", len(labels))
+		line, err := reader.ReadString('\n')
+		answer := strings.TrimSpace(line)
+		if n, convErr := strconv.Atoi(answer); convErr == nil && n >= 1 && n <= len(labels) {
+			if err != nil {
+				fmt.Fprintln(ui)
+			}
+			return n - 1, nil
+		}
+		if err != nil {
+			fmt.Fprintln(ui)
+			return 0, usageError(fmt.Errorf("no branch selected"))
+		}
+		fmt.Fprintf(ui, "❌ '%s' is not one of the choices\n", answer)
+	}
+}
@@ -0,0 +1,183 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/gizzahub/gzh-cli-gitflow/internal/gitcmd"
+	"github.com/gizzahub/gzh-cli-gitflow/internal/preflight"
+	"github.com/gizzahub/gzh-cli-gitflow/internal/state"
+	"github.com/gizzahub/gzh-cli-gitflow/pkg/config"
+)
+
+// dryRun makes start/finish commands print their plan instead of running it
+var dryRun bool
+
+// runPreflight runs the pre-flight checks of an operation merging source into
+// targets and prints the results. The checks are the built-in ones plus
+// preflight.custom, selected by the preflight config of the operation.
+func runPreflight(ctx context.Context, git *gitcmd.Executor, cfg *config.Config, operation, source string, targets ...string) preflight.Results {
+	op := preflight.Operation{
+		Name:    operation,
+		Source:  source,
+		Targets: targets,
+		Strict:  cfg.Guardian.Enabled && cfg.Guardian.Mode == "strict",
+	}
+	// Repositories without the remote have nothing to compare with
+	if exists, _ := git.RemoteExists(ctx, cfg.Options.Remote); exists {
+		op.Remote = cfg.Options.Remote
+		// A dry run changes nothing, remote-tracking branches included
+		op.Fetch = cfg.Preflight.Fetch && !noFetch && !dryRun
+	}
+
+	checks, err := selectChecks(cfg, operation)
+	var results preflight.Results
+	if err != nil {
+		results = preflight.Results{{
+			Name:   "Pre-flight configuration",
+			Passed: false,
+			Error:  err.Error(),
+			Hint:   "Fix the preflight section of your configuration",
+		}}
+	} else {
+		results = preflight.NewChecker(git, "").WithOperation(op).WithChecks(checks...).RunAll(ctx)
+	}
+	opReport.Preflight = results
+
+	fmt.Fprintln(ui, "🔍 Pre-flight checks:")
+	fmt.Fprint(ui, results.String())
+	fmt.Fprintln(ui)
+
+	// Warnings only count if the operation goes ahead; a failed fetch is no
+	// guardian violation
+	var violations []state.Violation
+	for _, w := range results.Warnings() {
+		if w.Check == preflight.CheckFetch {
+			continue
+		}
+		message := w.Name
+		if w.Error != "" {
+			message += ": " + w.Error
+		}
+		violations = append(violations, state.Violation{Rule: w.Check, Branch: source, Message: message})
+	}
+	if len(violations) > 0 && !results.HasErrors() && !dryRun {
+		recordViolations(ctx, git, operation, violations...)
+	}
+	return results
+}
+
+// selectChecks returns the checks an operation runs: the built-in checks and
+// the custom checks for the operation, minus preflight.disabled and the
+// operation's disable list, plus its enable list. --allow-conflicts and the
+// workflow rules turn their checks on or off (see workflowRule); rules with
+// severity warn only warn.
+func selectChecks(cfg *config.Config, operation string) ([]preflight.Check, error) {
+	registry := preflight.NewRegistry()
+	opChecks := cfg.Preflight.ForOperation(operation)
+
+	disable := append([]string{}, cfg.Preflight.Disabled...)
+	for _, custom := range cfg.Preflight.Custom {
+		check := &preflight.CommandCheck{
+			CheckName: custom.Name,
+			Command:   custom.Run,
+			Hint:      custom.Hint,
+			Timeout:   custom.TimeoutDuration(),
+		}
+		if err := registry.Register(check); err != nil {
+			return nil, err
+		}
+		if !custom.AppliesTo(operation) {
+			disable = append(disable, custom.Name)
+		}
+	}
+	disable = append(disable, opChecks.Disable...)
+
+	// Flags and rules win over the enable list
+	checks, err := registry.Select(opChecks.Enable, disable)
+	if err != nil {
+		return nil, err
+	}
+	var selected []preflight.Check
+	for _, check := range checks {
+		if check.Name() == preflight.CheckMergeConflicts && allowConflicts {
+			continue
+		}
+		rule, on := workflowRule(cfg, operation, check.Name())
+		if !on {
+			continue
+		}
+		if rule == "" {
+			selected = append(selected, check)
+			continue
+		}
+		switch cfg.Guardian.SeverityOf(rule) {
+		case config.SeverityOff:
+		case config.SeverityWarn:
+			selected = append(selected, preflight.Advisory(check))
+		default:
+			selected = append(selected, check)
+		}
+	}
+	return selected, nil
+}
+
+// workflowRule returns the guardian rule behind a check, or "" for checks no
+// rule governs, and whether the check runs for the operation:
+//   - clean-tree and untracked-files need options.require_clean_tree and
+//     guardian.workflow.require_clean_tree
+//   - up-to-date needs guardian.workflow.require_up_to_date
+//   - linear-history needs guardian.workflow.require_linear_history and only
+//     applies to features; releases and hotfixes are merged back by design
+//
+// Without guardian.enabled no rule applies: clean-tree and untracked-files
+// follow options.require_clean_tree alone, up-to-date always runs, and
+// linear-history, which only exists for its rule, does not run.
+func workflowRule(cfg *config.Config, operation, check string) (string, bool) {
+	if !cfg.Guardian.Enabled {
+		switch check {
+		case preflight.CheckCleanTree, preflight.CheckUntrackedFiles:
+			return "", cfg.Options.RequireCleanTree
+		case preflight.CheckLinearHistory:
+			return "", false
+		}
+		return "", true
+	}
+
+	rules := cfg.Guardian.Workflow
+	switch check {
+	case preflight.CheckCleanTree, preflight.CheckUntrackedFiles:
+		return config.RuleRequireCleanTree, cfg.Options.RequireCleanTree && rules.RequireCleanTree
+	case preflight.CheckUpToDate:
+		return config.RuleRequireUpToDate, rules.RequireUpToDate
+	case preflight.CheckLinearHistory:
+		return config.RuleRequireLinearHistory, rules.RequireLinearHistory && operation == opFeatureFinish
+	}
+	return "", true
+}
+
+// linearHistory reports whether the operation must keep the history linear:
+// guardian.workflow.require_linear_history applies to it with severity error.
+// The linear-history check then refuses a source that is not rebased, and the
+// merge is a fast-forward instead of a merge commit.
+func linearHistory(cfg *config.Config, operation string) bool {
+	rule, on := workflowRule(cfg, operation, preflight.CheckLinearHistory)
+	return on && rule != "" && cfg.Guardian.SeverityOf(rule) == config.SeverityError
+}
+
+// printPlan shows the git commands a dry run would perform. It fails if the
+// pre-flight checks did, since the real run would stop before the first step.
+func printPlan(plan gitcmd.Plan, results preflight.Results) error {
+	opReport.Status = statusDryRun
+	opReport.Pending = plan.Commands()
+
+	fmt.Fprintln(ui, "📋 Dry run: these git commands would run:")
+	fmt.Fprint(ui, plan.String())
+	fmt.Fprintln(ui)
+
+	if results.HasErrors() {
+		return withExitCode(exitPreflight, fmt.Errorf("pre-flight checks failed; the plan would not run"))
+	}
+	fmt.Fprintln(ui, "💡 Nothing was changed; run again without --dry-run to apply")
+	return nil
+}
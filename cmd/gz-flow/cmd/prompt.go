@@ -0,0 +1,51 @@
+package cmd
+
+import (
+	"bufio"
+	"fmt"
+	"os"
+	"strings"
+
+	"golang.org/x/term"
+)
+
+// isInteractive reports whether stdin is attached to a terminal. Character
+// devices such as /dev/null are not.
+func isInteractive() bool {
+	return term.IsTerminal(int(os.Stdin.Fd()))
+}
+
+// promptValue asks a question and returns the answer, or def if the answer is empty
+func promptValue(reader *bufio.Reader, question, def string) string {
+	fmt.Fprintf(ui, "%s [%s]: ", question, def)
+	line, err := reader.ReadString('\n')
+	if err != nil && line == "" {
+		fmt.Fprintln(ui)
+		return def
+	}
+	if answer := strings.TrimSpace(line); answer != "" {
+		return answer
+	}
+	return def
+}
+
+// promptConfirm asks a yes/no question and returns def if the answer is empty
+func promptConfirm(reader *bufio.Reader, question string, def bool) bool {
+	choices := "y/N"
+	if def {
+		choices = "Y/n"
+	}
+	fmt.Fprintf(ui, "%s [%s]: ", question, choices)
+	line, err := reader.ReadString('\n')
+	if err != nil && line == "" {
+		fmt.Fprintln(ui)
+		return def
+	}
+	switch strings.ToLower(strings.TrimSpace(line)) {
+	case "y", "yes":
+		return true
+	case "n", "no":
+		return false
+	}
+	return def
+}
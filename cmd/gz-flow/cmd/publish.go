@@ -0,0 +1,153 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/spf13/cobra"
+
+	"github.com/gizzahub/gzh-cli-gitflow/internal/gitcmd"
+	"github.com/gizzahub/gzh-cli-gitflow/internal/validator"
+	"github.com/gizzahub/gzh-cli-gitflow/pkg/config"
+)
+
+// remoteName overrides options.remote for publish, track and pull
+var remoteName string
+
+// newPublishCmd creates the publish subcommand for a flow branch type
+func newPublishCmd(t config.BranchType) *cobra.Command {
+	c := &cobra.Command{
+		Use:   "publish [name]",
+		Short: fmt.Sprintf("Push a %s branch to the remote", t),
+		Long: fmt.Sprintf(`Push a %[1]s branch to the remote and set it as the upstream.
+
+The remote is options.remote (default: origin) unless --remote is given.
+Without a name, the current %[1]s branch is published.
+
+Example:
+  gz-flow %[1]s publish
+  gz-flow %[1]s publish %[2]s
+  gz-flow %[1]s publish %[2]s --remote upstream`, t, exampleName(t)),
+		Args: cobra.MaximumNArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return runPublish(t, args)
+		},
+	}
+	c.Flags().StringVar(&remoteName, "remote", "", "Remote to push to (default: options.remote)")
+	return c
+}
+
+func runPublish(t config.BranchType, args []string) error {
+	report := beginReport(string(t)+" publish", args)
+
+	if err := checkGitRepo(); err != nil {
+		return err
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 60*time.Second)
+	defer cancel()
+
+	git := gitcmd.New()
+	cfg, err := loadConfig()
+	if err != nil {
+		return err
+	}
+
+	// 1. Determine the branch to publish
+	name, err := flowBranchName(ctx, git, cfg, t, args)
+	if err != nil {
+		return err
+	}
+	report.Name = name
+	branch := cfg.Prefix(t) + name
+
+	exists, _ := git.BranchExists(ctx, branch)
+	if !exists {
+		return fmt.Errorf("%s branch '%s' does not exist", t, branch)
+	}
+
+	// 2. Validate the remote
+	remote, err := resolveRemote(ctx, git, cfg)
+	if err != nil {
+		return err
+	}
+
+	// 3. Push with upstream tracking
+	plan := gitcmd.Plan{
+		{Op: gitcmd.OpPush, Branch: branch, Remote: remote, Upstream: true},
+	}
+	if dryRun {
+		return printPlan(plan, nil)
+	}
+	if err := git.Execute(ctx, plan); err != nil {
+		return fmt.Errorf("failed to publish %s: %v\n💡 Check your access to '%s' with 'git remote -v'", branch, err, remote)
+	}
+	report.Branch = branch
+	report.Completed = plan.Commands()
+
+	fmt.Fprintf(ui, "✅ Published '%s' to '%s'\n", branch, remote)
+	fmt.Fprintf(ui, "📍 '%s' now tracks '%s/%s'\n", branch, remote, branch)
+
+	return nil
+}
+
+// flowBranchName returns the flow branch name from args, or from the current
+// branch if it is of type t. Versions are validated for release and hotfix.
+func flowBranchName(ctx context.Context, git *gitcmd.Executor, cfg *config.Config, t config.BranchType, args []string) (string, error) {
+	if len(args) > 0 {
+		name := strings.TrimPrefix(args[0], cfg.Prefix(t))
+		if t != config.BranchFeature {
+			if err := validator.ValidateVersion(name); err != nil {
+				return "", usageError(fmt.Errorf("invalid version: %v", err))
+			}
+		}
+		return name, nil
+	}
+
+	current, err := git.CurrentBranch(ctx)
+	if err != nil {
+		return "", fmt.Errorf("failed to get current branch: %v", err)
+	}
+	currentType, name := cfg.ClassifyBranch(current)
+	if currentType != t {
+		return "", usageError(fmt.Errorf("not on a %s branch (current: %s)\n💡 Pass the %s name or switch to a %s branch", t, current, t, t))
+	}
+	fmt.Fprintf(ui, "📍 Auto-detected %s: %s\n\n", t, name)
+	return name, nil
+}
+
+// resolveRemote returns the remote selected by --remote or options.remote,
+// failing if it is not configured in this repository
+func resolveRemote(ctx context.Context, git *gitcmd.Executor, cfg *config.Config) (string, error) {
+	remote := cfg.Options.Remote
+	if remoteName != "" {
+		remote = remoteName
+	}
+
+	exists, err := git.RemoteExists(ctx, remote)
+	if err != nil {
+		return "", fmt.Errorf("failed to check remote '%s': %v", remote, err)
+	}
+	if !exists {
+		remotes, _ := git.Remotes(ctx)
+		hint := fmt.Sprintf("💡 Add it with 'git remote add %s <url>'", remote)
+		if len(remotes) > 0 {
+			hint += fmt.Sprintf(", or use --remote (available: %s)", strings.Join(remotes, ", "))
+		}
+		return "", fmt.Errorf("remote '%s' does not exist\n%s", remote, hint)
+	}
+	return remote, nil
+}
+
+// exampleName returns a sample name for help texts
+func exampleName(t config.BranchType) string {
+	switch t {
+	case config.BranchRelease:
+		return "1.2.0"
+	case config.BranchHotfix:
+		return "1.2.1"
+	}
+	return "user-authentication"
+}
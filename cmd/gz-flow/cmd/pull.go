@@ -0,0 +1,177 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/spf13/cobra"
+
+	"github.com/gizzahub/gzh-cli-gitflow/internal/gitcmd"
+	"github.com/gizzahub/gzh-cli-gitflow/pkg/config"
+)
+
+// pullRebase makes pull rebase local commits instead of merging
+var pullRebase bool
+
+// newPullCmd creates the pull subcommand for a flow branch type
+func newPullCmd(t config.BranchType) *cobra.Command {
+	c := &cobra.Command{
+		Use:   "pull [name]",
+		Short: fmt.Sprintf("Update a %s branch with remote changes", t),
+		Long: fmt.Sprintf(`Fetch a %[1]s branch from the remote and merge the remote changes
+into the local branch, or rebase local commits on top of them with --rebase.
+
+Without a name, the current %[1]s branch is updated. Conflicting files are
+listed so you can resolve them or abort.
+
+Example:
+  gz-flow %[1]s pull
+  gz-flow %[1]s pull %[2]s --rebase`, t, exampleName(t)),
+		Args: cobra.MaximumNArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return runPull(t, args)
+		},
+	}
+	c.Flags().StringVar(&remoteName, "remote", "", "Remote to pull from (default: options.remote)")
+	c.Flags().BoolVar(&pullRebase, "rebase", false, "Rebase local commits onto the remote branch instead of merging")
+	return c
+}
+
+func runPull(t config.BranchType, args []string) error {
+	report := beginReport(string(t)+" pull", args)
+
+	if err := checkGitRepo(); err != nil {
+		return err
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 60*time.Second)
+	defer cancel()
+
+	git := gitcmd.New()
+	cfg, err := loadConfig()
+	if err != nil {
+		return err
+	}
+
+	// 1. Determine the branch and the remote
+	name, err := flowBranchName(ctx, git, cfg, t, args)
+	if err != nil {
+		return err
+	}
+	report.Name = name
+	branch := cfg.Prefix(t) + name
+
+	exists, _ := git.BranchExists(ctx, branch)
+	if !exists {
+		return fmt.Errorf("%s branch '%s' does not exist locally\n💡 Use 'gz-flow %s track %s' to check it out", t, branch, t, name)
+	}
+
+	remote, err := resolveRemote(ctx, git, cfg)
+	if err != nil {
+		return err
+	}
+	remoteBranch := remote + "/" + branch
+
+	// 2. Merging or rebasing needs a clean working tree; untracked files are
+	// left alone by both
+	modified, err := git.ModifiedFiles(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to check working tree: %v", err)
+	}
+	if len(modified) > 0 {
+		return withExitCode(exitPreflight, fmt.Errorf("working directory has uncommitted changes\n💡 Commit or stash your changes first"))
+	}
+
+	// 3. Build the plan
+	plan := gitcmd.Plan{{Op: gitcmd.OpFetch, Branch: branch, Remote: remote}}
+	if current, _ := git.CurrentBranch(ctx); current != branch {
+		plan = append(plan, gitcmd.Step{Op: gitcmd.OpCheckout, Branch: branch})
+	}
+	update := gitcmd.Step{Op: gitcmd.OpMerge, Branch: remoteBranch, Into: branch}
+	if pullRebase {
+		update = gitcmd.Step{Op: gitcmd.OpRebase, Branch: remoteBranch}
+	}
+	plan = append(plan, update)
+
+	if dryRun {
+		return printPlan(plan, nil)
+	}
+
+	// 4. Fetch, and only switch once the remote branch is known to exist
+	if err := git.Apply(ctx, plan[0]); err != nil {
+		return fmt.Errorf("failed to fetch '%s': %v\n💡 Check that it was published with 'gz-flow %s publish'", remoteBranch, err, t)
+	}
+	report.Completed = append(report.Completed, plan[0].String())
+
+	remoteExists, err := git.RemoteBranchExists(ctx, remote, branch)
+	if err != nil {
+		return err
+	}
+	if !remoteExists {
+		return fmt.Errorf("'%s' does not exist\n💡 Publish it first with 'gz-flow %s publish'", remoteBranch, t)
+	}
+
+	for _, step := range plan[1 : len(plan)-1] {
+		if err := git.Apply(ctx, step); err != nil {
+			return fmt.Errorf("%s failed: %v", step, err)
+		}
+		report.Completed = append(report.Completed, step.String())
+	}
+
+	ahead, behind, err := git.AheadBehind(ctx, branch, remoteBranch)
+	if err != nil {
+		return fmt.Errorf("failed to compare with %s: %v", remoteBranch, err)
+	}
+	if behind == 0 {
+		fmt.Fprintf(ui, "✅ '%s' is up to date with '%s'", branch, remoteBranch)
+		if ahead > 0 {
+			fmt.Fprintf(ui, " (%d local commit(s) not pushed)", ahead)
+		}
+		fmt.Fprintln(ui)
+		return nil
+	}
+
+	// 5. Merge or rebase the remote changes
+	report.Branch = branch
+	if err := git.Apply(ctx, update); err != nil {
+		return pullConflict(ctx, git, update, err)
+	}
+	report.Completed = append(report.Completed, update.String())
+
+	if pullRebase {
+		fmt.Fprintf(ui, "✅ Rebased %d local commit(s) of '%s' onto %d new commit(s) from '%s'\n", ahead, branch, behind, remoteBranch)
+	} else {
+		fmt.Fprintf(ui, "✅ Merged %d new commit(s) from '%s' into '%s'\n", behind, remoteBranch, branch)
+	}
+
+	return nil
+}
+
+// pullConflict reports the files a failed merge or rebase left in conflict
+// and how to finish or abort it
+func pullConflict(ctx context.Context, git *gitcmd.Executor, step gitcmd.Step, cause error) error {
+	files, _ := git.UnmergedFiles(ctx)
+	if len(files) == 0 {
+		return fmt.Errorf("%s failed: %v", step, cause)
+	}
+
+	opReport.Status = statusInterrupted
+	opReport.Conflicts = files
+	opReport.Pending = []string{step.String()}
+
+	fmt.Fprintf(ui, "\n⏸️  %s stopped on conflicts:\n", step)
+	for _, f := range files {
+		fmt.Fprintf(ui, "  ❌ %s\n", f)
+	}
+
+	if step.Op == gitcmd.OpRebase {
+		fmt.Fprintln(ui, "\n💡 Resolve the conflicts, 'git add' the files, then run 'git rebase --continue'")
+		fmt.Fprintln(ui, "💡 Or undo the pull with 'git rebase --abort'")
+	} else {
+		fmt.Fprintln(ui, "\n💡 Resolve the conflicts, 'git add' the files, then run 'git commit'")
+		fmt.Fprintln(ui, "💡 Or undo the pull with 'git merge --abort'")
+	}
+
+	return withExitCode(exitInterrupted, fmt.Errorf("pull stopped on %d conflicting file(s)", len(files)))
+}
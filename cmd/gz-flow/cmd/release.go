@@ -8,7 +8,6 @@ import (
 	"github.com/spf13/cobra"
 
 	"github.com/gizzahub/gzh-cli-gitflow/internal/gitcmd"
-	"github.com/gizzahub/gzh-cli-gitflow/internal/preflight"
 	"github.com/gizzahub/gzh-cli-gitflow/internal/validator"
 	"github.com/gizzahub/gzh-cli-gitflow/pkg/config"
 )
@@ -23,7 +22,10 @@ They allow for last-minute dotting of i's and crossing t's.
 
 Commands:
   start   - Start a new release branch from develop
-  finish  - Finish a release branch (merge to master and develop, tag)`,
+  finish  - Finish a release branch (merge to master and develop, tag)
+  publish - Push a release branch to the remote
+  track   - Check out a release branch from the remote
+  pull    - Update a release branch with remote changes`,
 }
 
 var releaseStartCmd = &cobra.Command{
@@ -39,7 +41,7 @@ Example:
 }
 
 var releaseFinishCmd = &cobra.Command{
-	Use:   "finish <version>",
+	Use:   "finish [version]",
 	Short: "Finish a release branch",
 	Long: `Finish a release branch by merging it into master and develop.
 
@@ -49,9 +51,19 @@ This will:
   - Merge the release branch into develop
   - Delete the release branch
 
+The original position of every branch is recorded before the first
+step. If a step fails you are offered an automatic rollback (always
+done with --rollback) that resets the branches and deletes the tag.
+
+If a merge stops on a conflict, resolve it and run --continue to
+finish the remaining steps, or run --abort to restore master and
+develop and delete the tag.
+
 Example:
-  gz-flow release finish 1.0.0`,
-	Args: cobra.ExactArgs(1),
+  gz-flow release finish 1.0.0
+  gz-flow release finish --continue
+  gz-flow release finish --abort`,
+	Args: cobra.MaximumNArgs(1),
 	RunE: runReleaseFinish,
 }
 
@@ -65,13 +77,19 @@ func init() {
 
 	releaseCmd.AddCommand(releaseStartCmd)
 	releaseCmd.AddCommand(releaseFinishCmd)
+	releaseCmd.AddCommand(newPublishCmd(config.BranchRelease))
+	releaseCmd.AddCommand(newTrackCmd(config.BranchRelease))
+	releaseCmd.AddCommand(newPullCmd(config.BranchRelease))
 
 	releaseFinishCmd.Flags().StringVarP(&tagMessage, "message", "m", "", "Tag message")
 	releaseFinishCmd.Flags().BoolVar(&noTag, "no-tag", false, "Don't create a tag")
 	releaseFinishCmd.Flags().BoolVarP(&keepBranch, "keep", "k", false, "Keep the release branch")
+	addFinishFlags(releaseFinishCmd)
 }
 
 func runReleaseStart(cmd *cobra.Command, args []string) error {
+	report := beginReport("release start", args)
+
 	if err := checkGitRepo(); err != nil {
 		return err
 	}
@@ -84,14 +102,13 @@ func runReleaseStart(cmd *cobra.Command, args []string) error {
 
 	// 1. Validate version format (strict semver)
 	if err := validator.ValidateVersion(version); err != nil {
-		return fmt.Errorf("invalid version: %v\n💡 Use semver format: 1.0.0", err)
+		return usageError(fmt.Errorf("invalid version: %v\n💡 Use semver format: 1.0.0", err))
 	}
 
 	// 2. Load config
-	cfg, err := config.LoadFromDir(".")
+	cfg, err := loadConfig()
 	if err != nil {
-		fmt.Printf("⚠️  Failed to load config, using defaults: %v\n", err)
-		cfg = config.Default()
+		return err
 	}
 
 	// 3. Check if release branch already exists
@@ -104,26 +121,33 @@ func runReleaseStart(cmd *cobra.Command, args []string) error {
 	// 4. Context hint: warn if not on develop
 	currentBranch, _ := git.CurrentBranch(ctx)
 	if currentBranch != cfg.Branches.Develop {
-		fmt.Printf("⚠️  You're on '%s', not '%s'\n", currentBranch, cfg.Branches.Develop)
-		fmt.Printf("💡 Will checkout '%s' first\n\n", cfg.Branches.Develop)
+		fmt.Fprintf(ui, "⚠️  You're on '%s', not '%s'\n", currentBranch, cfg.Branches.Develop)
+		fmt.Fprintf(ui, "💡 Will checkout '%s' first\n\n", cfg.Branches.Develop)
 	}
 
 	// 5. Create release branch from develop
-	if err := git.Checkout(ctx, cfg.Branches.Develop); err != nil {
-		return fmt.Errorf("failed to checkout %s: %v", cfg.Branches.Develop, err)
+	plan := gitcmd.Plan{
+		{Op: gitcmd.OpCheckout, Branch: cfg.Branches.Develop},
+		{Op: gitcmd.OpCreateBranch, Branch: releaseBranch},
 	}
-
-	if err := git.CreateBranch(ctx, releaseBranch); err != nil {
-		return fmt.Errorf("failed to create branch: %v", err)
+	if dryRun {
+		return printPlan(plan, nil)
 	}
+	if err := git.Execute(ctx, plan); err != nil {
+		return err
+	}
+	report.Branch = releaseBranch
+	report.Completed = plan.Commands()
 
-	fmt.Printf("✅ Started release branch '%s'\n", releaseBranch)
-	fmt.Printf("📍 Switched to branch '%s'\n", releaseBranch)
+	fmt.Fprintf(ui, "✅ Started release branch '%s'\n", releaseBranch)
+	fmt.Fprintf(ui, "📍 Switched to branch '%s'\n", releaseBranch)
 
 	return nil
 }
 
 func runReleaseFinish(cmd *cobra.Command, args []string) error {
+	beginReport(opReleaseFinish, args)
+
 	if err := checkGitRepo(); err != nil {
 		return err
 	}
@@ -132,18 +156,32 @@ func runReleaseFinish(cmd *cobra.Command, args []string) error {
 	defer cancel()
 
 	git := gitcmd.New()
+	if resuming() {
+		return resumeFinish(ctx, git, opReleaseFinish)
+	}
+	if err := checkNoFinishInProgress(ctx, git); err != nil {
+		return err
+	}
+	if pickFinish {
+		var err error
+		if args, err = pickArgs(ctx, git, config.BranchRelease, args); err != nil {
+			return err
+		}
+	}
+	if len(args) == 0 {
+		return usageError(fmt.Errorf("version is required\nUsage: gz-flow release finish <version>\n💡 Or choose the branch with --pick"))
+	}
 	version := args[0]
 
 	// 1. Validate version
 	if err := validator.ValidateVersion(version); err != nil {
-		return fmt.Errorf("invalid version: %v", err)
+		return usageError(fmt.Errorf("invalid version: %v", err))
 	}
 
 	// 2. Load config
-	cfg, err := config.LoadFromDir(".")
+	cfg, err := loadConfig()
 	if err != nil {
-		fmt.Printf("⚠️  Failed to load config, using defaults: %v\n", err)
-		cfg = config.Default()
+		return err
 	}
 
 	releaseBranch := cfg.Prefixes.Release + version
@@ -156,81 +194,62 @@ func runReleaseFinish(cmd *cobra.Command, args []string) error {
 		return fmt.Errorf("release branch '%s' does not exist", releaseBranch)
 	}
 
-	// 4. Pre-flight checks
-	checker := preflight.NewChecker(git, masterBranch)
-	results := checker.RunAll(ctx)
-	fmt.Println("🔍 Pre-flight checks:")
-	fmt.Print(results.String())
-	if results.HasErrors() {
-		return fmt.Errorf("pre-flight checks failed")
-	}
-	fmt.Println()
-
-	// 5. STEP 1: Merge to master (--no-ff)
-	if err := git.Checkout(ctx, masterBranch); err != nil {
-		return fmt.Errorf("failed to checkout %s: %v", masterBranch, err)
-	}
-
-	if err := git.Merge(ctx, releaseBranch, true); err != nil {
-		return fmt.Errorf("merge to %s failed: %v\n💡 Resolve conflicts:\n  1. git checkout %s\n  2. git merge --no-ff %s\n  3. Resolve conflicts\n  4. git merge --continue\n  5. Retry: gz-flow release finish %s",
-			masterBranch, err, masterBranch, releaseBranch, version)
-	}
-	fmt.Printf("✅ Merged '%s' into '%s'\n", releaseBranch, masterBranch)
-
-	// 6. STEP 2: Create tag on master
+	// Check the tag before touching any branch
+	tagName := fmt.Sprintf(cfg.Options.TagFormat, version)
 	if !noTag {
-		tagName := fmt.Sprintf(cfg.Options.TagFormat, version)
-
-		// Check if tag already exists
 		tagExists, _ := git.TagExists(ctx, tagName)
 		if tagExists {
 			return fmt.Errorf("tag '%s' already exists\n💡 Use different version or delete existing tag", tagName)
 		}
+	}
 
+	// 4. Pre-flight checks
+	results := runPreflight(ctx, git, cfg, opReleaseFinish, releaseBranch, masterBranch, developBranch)
+	if results.HasErrors() && !dryRun {
+		return withExitCode(exitPreflight, fmt.Errorf("pre-flight checks failed"))
+	}
+	// Custom checks may outlast the timeout; restart it for the steps
+	ctx, cancel = context.WithTimeout(context.Background(), 60*time.Second)
+	defer cancel()
+
+	// 5. Merge to master (--no-ff) and tag it
+	plan := gitcmd.Plan{
+		{Op: gitcmd.OpCheckout, Branch: masterBranch},
+		{Op: gitcmd.OpMerge, Branch: releaseBranch, Into: masterBranch, NoFF: true},
+	}
+	if !noTag {
 		message := tagMessage
 		if message == "" {
 			message = fmt.Sprintf("Release version %s", version)
 		}
-
-		if err := git.CreateTag(ctx, tagName, message); err != nil {
-			return fmt.Errorf("failed to create tag: %v", err)
-		}
-		fmt.Printf("🏷️  Created tag '%s'\n", tagName)
+		plan = append(plan, gitcmd.Step{Op: gitcmd.OpTag, Tag: tagName, Message: message})
 	}
 
-	// 7. STEP 3: Merge to develop
+	// 6. Merge to develop
 	developExists, _ := git.BranchExists(ctx, developBranch)
 	if !developExists {
-		fmt.Printf("⚠️  Develop branch '%s' does not exist\n", developBranch)
-		fmt.Printf("💡 Skipping merge to develop\n")
+		fmt.Fprintf(ui, "⚠️  Develop branch '%s' does not exist\n", developBranch)
+		fmt.Fprintf(ui, "💡 Skipping merge to develop\n")
 	} else {
-		if err := git.Checkout(ctx, developBranch); err != nil {
-			return fmt.Errorf("failed to checkout %s: %v", developBranch, err)
-		}
+		plan = append(plan,
+			gitcmd.Step{Op: gitcmd.OpCheckout, Branch: developBranch},
+			gitcmd.Step{Op: gitcmd.OpMerge, Branch: releaseBranch, Into: developBranch, NoFF: true},
+		)
+	}
 
-		if err := git.Merge(ctx, releaseBranch, true); err != nil {
-			tagName := fmt.Sprintf(cfg.Options.TagFormat, version)
-			fmt.Printf("⚠️  PARTIAL SUCCESS:\n")
-			fmt.Printf("  ✅ Merged to %s and tagged '%s'\n", masterBranch, tagName)
-			fmt.Printf("  ❌ Merge to %s failed: %v\n", developBranch, err)
-			fmt.Printf("\n💡 To complete:\n")
-			fmt.Printf("  1. git checkout %s\n", developBranch)
-			fmt.Printf("  2. git merge --no-ff %s\n", releaseBranch)
-			fmt.Printf("  3. Resolve conflicts and commit\n")
-			return err
-		}
-		fmt.Printf("✅ Merged '%s' into '%s'\n", releaseBranch, developBranch)
+	// 7. Delete release branch
+	if cfg.Options.DeleteBranchAfterFinish && !keepBranch {
+		plan = append(plan, gitcmd.Step{Op: gitcmd.OpDeleteBranch, Branch: releaseBranch, Optional: true})
 	}
 
-	// 8. STEP 4: Delete release branch
-	deleteBranch := cfg.Options.DeleteBranchAfterFinish && !keepBranch
-	if deleteBranch {
-		if err := git.DeleteBranch(ctx, releaseBranch); err != nil {
-			fmt.Printf("⚠️  Failed to delete branch: %v\n", err)
-		} else {
-			fmt.Printf("🗑️  Deleted branch '%s'\n", releaseBranch)
-		}
+	// 8. Push the result if options.push_after_finish is on
+	push, err := pushPlan(ctx, git, cfg, plan)
+	if err != nil {
+		return err
 	}
 
-	return nil
+	if dryRun {
+		return printPlan(append(plan, push...), results)
+	}
+	return executeFinish(ctx, git, opReleaseFinish, version, plan, push)
 }
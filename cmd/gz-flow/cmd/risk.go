@@ -0,0 +1,77 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"time"
+
+	"github.com/gizzahub/gzh-cli-gitflow/internal/gitcmd"
+	"github.com/gizzahub/gzh-cli-gitflow/pkg/config"
+)
+
+// overlap is a file changed both by the running feature and another one
+type overlap struct {
+	Path       string    `json:"path" yaml:"path"`
+	Branch     string    `json:"branch" yaml:"branch"`
+	Author     string    `json:"author" yaml:"author"`
+	LastCommit time.Time `json:"last_commit" yaml:"last_commit"`
+}
+
+// findOverlaps returns the files that other active feature branches change
+// too, each branch diffed against its merge-base with develop
+func findOverlaps(ctx context.Context, git *gitcmd.Executor, cfg *config.Config, branch string, files []string) ([]overlap, error) {
+	if len(files) == 0 {
+		return nil, nil
+	}
+	mine := make(map[string]bool, len(files))
+	for _, f := range files {
+		mine[f] = true
+	}
+
+	others, err := git.ListBranchInfo(ctx, cfg.Prefixes.Feature)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list feature branches: %w", err)
+	}
+	var overlaps []overlap
+	for _, other := range others {
+		if other.Name == branch {
+			continue
+		}
+		theirs, err := git.ChangedFiles(ctx, cfg.Branches.Develop, other.Name)
+		if err != nil {
+			return nil, fmt.Errorf("failed to diff %s: %w", other.Name, err)
+		}
+		for _, f := range theirs {
+			if mine[f] {
+				overlaps = append(overlaps, overlap{Path: f, Branch: other.Name, Author: other.Author, LastCommit: other.LastCommit})
+			}
+		}
+	}
+	sort.SliceStable(overlaps, func(i, j int) bool { return overlaps[i].Path < overlaps[j].Path })
+	return overlaps, nil
+}
+
+// warnConflictRisk prints the files that other features change as well. It
+// only warns: a failed lookup is reported and never stops the operation.
+func warnConflictRisk(ctx context.Context, git *gitcmd.Executor, cfg *config.Config, branch string, files []string, hint string) {
+	overlaps, err := findOverlaps(ctx, git, cfg, branch, files)
+	if err != nil {
+		fmt.Fprintf(ui, "⚠️  Conflict risk analysis skipped: %v\n\n", err)
+		return
+	}
+	opReport.Overlaps = overlaps
+	if len(overlaps) == 0 {
+		return
+	}
+
+	fmt.Fprintln(ui, "🔍 Conflict Risk Analysis")
+	fmt.Fprintln(ui, "─────────────────────────────────────")
+	for i, o := range overlaps {
+		if i == 0 || overlaps[i-1].Path != o.Path {
+			fmt.Fprintf(ui, "⚠️  %s - also modified in:\n", o.Path)
+		}
+		fmt.Fprintf(ui, "   └─ %s (%s, %s)\n", o.Branch, o.Author, humanizeAge(time.Since(o.LastCommit)))
+	}
+	fmt.Fprintf(ui, "💡 %s\n\n", hint)
+}
@@ -1,10 +1,13 @@
 package cmd
 
 import (
+	"errors"
 	"fmt"
 	"os"
 
 	"github.com/spf13/cobra"
+
+	"github.com/gizzahub/gzh-cli-gitflow/pkg/config"
 )
 
 var (
@@ -26,12 +29,22 @@ It provides commands for managing git-flow branches:
 Example:
   gz-flow init                    # Initialize git-flow
   gz-flow feature start my-feat   # Start a feature branch
-  gz-flow feature finish my-feat  # Finish the feature`,
+  gz-flow feature finish my-feat  # Finish the feature
+  gz-flow status --output json    # Machine-readable status`,
+	PersistentPreRunE: setupOutput,
 }
 
-// Execute adds all child commands to the root command
+// Execute adds all child commands to the root command.
+// Use ExitCode to turn the returned error into the process exit code.
 func Execute() error {
-	return rootCmd.Execute()
+	classifyArgs(rootCmd)
+	err := rootCmd.Execute()
+	if structuredOutput() {
+		if werr := writeResult(os.Stdout, err); werr != nil && err == nil {
+			err = werr
+		}
+	}
+	return err
 }
 
 // SetVersion sets the version for the version command
@@ -40,14 +53,22 @@ func SetVersion(v string) {
 }
 
 func init() {
-	rootCmd.PersistentFlags().StringVar(&cfgFile, "config", "", "config file (default: ~/.gz/gitflow)")
+	rootCmd.PersistentFlags().StringVar(&cfgFile, "config", "", "config file applied on top of ~/.gz/gitflow, .gzflow.yaml and GZFLOW_* variables")
+	rootCmd.PersistentFlags().BoolVar(&dryRun, "dry-run", false, "print the git commands start/finish would run without changing anything")
+	rootCmd.PersistentFlags().StringVarP(&outputFormat, "output", "o", outputText, "output format: text, json, yaml")
+	rootCmd.SetFlagErrorFunc(func(c *cobra.Command, err error) error {
+		return usageError(err)
+	})
 
 	// Add version command
 	rootCmd.AddCommand(&cobra.Command{
 		Use:   "version",
 		Short: "Print the version number",
 		Run: func(cmd *cobra.Command, args []string) {
-			fmt.Printf("gz-flow version %s\n", version)
+			result = struct {
+				Version string `json:"version" yaml:"version"`
+			}{version}
+			fmt.Fprintf(ui, "gz-flow version %s\n", version)
 		},
 	})
 }
@@ -55,7 +76,36 @@ func init() {
 // checkGitRepo checks if current directory is a git repository
 func checkGitRepo() error {
 	if _, err := os.Stat(".git"); os.IsNotExist(err) {
-		return fmt.Errorf("not a git repository (or any of the parent directories)")
+		return withExitCode(exitNotRepo, fmt.Errorf("not a git repository (or any of the parent directories)"))
 	}
 	return nil
 }
+
+// loadConfig loads the layered configuration for the current directory.
+// Broken config files fall back to defaults with a warning, but an explicit
+// --config file that cannot be loaded is an error.
+func loadConfig() (*config.Config, error) {
+	cfg, _, err := config.LoadLayered(configLoadOptions())
+	if err != nil {
+		// Falling back to defaults would run with different branches than configured
+		var invalid *config.InvalidError
+		if errors.As(err, &invalid) {
+			return nil, withExitCode(exitConfig, fmt.Errorf("%v\n💡 Fix the value where it is set, e.g. gz-flow config <key> <value> or gz-flow config --unset <key>; gz-flow config --list --show-origin shows every value and its origin", err))
+		}
+		if cfgFile != "" {
+			return nil, withExitCode(exitConfig, err)
+		}
+		fmt.Fprintf(ui, "⚠️  Failed to load config, using defaults: %v\n", err)
+		return config.Default(), nil
+	}
+	return cfg, nil
+}
+
+// configLoadOptions returns the layers used for the current invocation
+func configLoadOptions() config.LoadOptions {
+	return config.LoadOptions{
+		Dir:        ".",
+		Environ:    os.Environ(),
+		ConfigFile: cfgFile,
+	}
+}
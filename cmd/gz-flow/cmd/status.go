@@ -1,9 +1,15 @@
 package cmd
 
 import (
+	"context"
 	"fmt"
+	"strings"
+	"time"
 
 	"github.com/spf13/cobra"
+
+	"github.com/gizzahub/gzh-cli-gitflow/internal/gitcmd"
+	"github.com/gizzahub/gzh-cli-gitflow/pkg/config"
 )
 
 var statusCmd = &cobra.Command{
@@ -13,6 +19,7 @@ var statusCmd = &cobra.Command{
 
 Displays:
   - Current branch and its type
+  - Commits ahead/behind develop and master
   - Active flow branches
   - Working directory status
 
@@ -25,29 +32,143 @@ func init() {
 	rootCmd.AddCommand(statusCmd)
 }
 
+// statusReport is the structured output of status
+type statusReport struct {
+	Branch  string                         `json:"branch" yaml:"branch"` // empty when HEAD is detached
+	Type    config.BranchType              `json:"type" yaml:"type"`
+	Base    string                         `json:"base,omitempty" yaml:"base,omitempty"`
+	Sync    []syncReport                   `json:"sync,omitempty" yaml:"sync,omitempty"`
+	Active  map[config.BranchType][]string `json:"active" yaml:"active"`
+	Clean   bool                           `json:"clean" yaml:"clean"`
+	Changes []gitcmd.FileStatus            `json:"changes" yaml:"changes"`
+}
+
+// syncReport compares the current branch with develop or master
+type syncReport struct {
+	Against string `json:"against" yaml:"against"`
+	Exists  bool   `json:"exists" yaml:"exists"`
+	Ahead   int    `json:"ahead" yaml:"ahead"`
+	Behind  int    `json:"behind" yaml:"behind"`
+	Error   string `json:"error,omitempty" yaml:"error,omitempty"`
+}
+
 func runStatus(cmd *cobra.Command, args []string) error {
 	if err := checkGitRepo(); err != nil {
 		return err
 	}
 
-	// TODO: Implement status logic
-	// 1. Get current branch
-	// 2. Determine branch type
+	ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+	defer cancel()
+
+	git := gitcmd.New()
+	cfg, err := loadConfig()
+	if err != nil {
+		return err
+	}
+
+	// 1. Get current branch and determine its type
+	currentBranch, err := git.CurrentBranch(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to get current branch: %v", err)
+	}
+
+	report := &statusReport{
+		Branch: currentBranch,
+		Type:   config.BranchOther,
+		Active: map[config.BranchType][]string{},
+	}
+
+	fmt.Fprintln(ui, "Git-flow Status")
+	fmt.Fprintln(ui, "===============")
+	fmt.Fprintln(ui, "")
+
+	if currentBranch == "" {
+		fmt.Fprintln(ui, "Current branch: (detached HEAD)")
+		fmt.Fprintln(ui, "Branch type: other")
+	} else {
+		report.Type, _ = cfg.ClassifyBranch(currentBranch)
+		report.Base = cfg.BaseBranch(report.Type)
+		fmt.Fprintf(ui, "Current branch: %s\n", currentBranch)
+		if report.Base != "" {
+			fmt.Fprintf(ui, "Branch type: %s (base: %s)\n", report.Type, report.Base)
+		} else {
+			fmt.Fprintf(ui, "Branch type: %s\n", report.Type)
+		}
+
+		// 2. Ahead/behind against develop and master
+		fmt.Fprintln(ui, "")
+		fmt.Fprintln(ui, "Sync:")
+		for _, base := range []string{cfg.Branches.Develop, cfg.Branches.Master} {
+			if base == currentBranch {
+				fmt.Fprintf(ui, "  vs %-8s %s\n", base+":", "(current)")
+				continue
+			}
+			sync := compareWith(ctx, git, currentBranch, base)
+			report.Sync = append(report.Sync, sync)
+			fmt.Fprintf(ui, "  vs %-8s %s\n", base+":", sync)
+		}
+	}
+
 	// 3. List active flow branches
+	fmt.Fprintln(ui, "")
+	fmt.Fprintln(ui, "Active branches:")
+	for _, t := range config.FlowTypes {
+		branches, err := git.ListBranches(ctx, cfg.Prefix(t))
+		if err != nil {
+			return fmt.Errorf("failed to list %s branches: %v", t, err)
+		}
+		report.Active[t] = append([]string{}, branches...)
+		list := "(none)"
+		if len(branches) > 0 {
+			list = strings.Join(branches, ", ")
+		}
+		fmt.Fprintf(ui, "  %-8s %s\n", string(t)+":", list)
+	}
+
 	// 4. Show working directory status
+	files, err := git.Status(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to get working directory status: %v", err)
+	}
+	report.Clean = len(files) == 0
+	report.Changes = append([]gitcmd.FileStatus{}, files...)
+	result = report
+
+	fmt.Fprintln(ui, "")
+	if len(files) == 0 {
+		fmt.Fprintln(ui, "Working directory: clean")
+		return nil
+	}
 
-	fmt.Println("Git-flow Status")
-	fmt.Println("===============")
-	fmt.Println("")
-	fmt.Println("Current branch: develop")
-	fmt.Println("Branch type: develop")
-	fmt.Println("")
-	fmt.Println("Active branches:")
-	fmt.Println("  feature: (none)")
-	fmt.Println("  release: (none)")
-	fmt.Println("  hotfix:  (none)")
-	fmt.Println("")
-	fmt.Println("Working directory: clean")
+	fmt.Fprintf(ui, "Working directory: %d changed file(s)\n", len(files))
+	for _, f := range files {
+		fmt.Fprintf(ui, "  %s %s\n", f.Code, f.Path)
+	}
 
 	return nil
 }
+
+// compareWith counts the commits branch is ahead of and behind base
+func compareWith(ctx context.Context, git *gitcmd.Executor, branch, base string) syncReport {
+	sync := syncReport{Against: base}
+	exists, err := git.BranchExists(ctx, base)
+	if err != nil || !exists {
+		return sync
+	}
+	sync.Exists = true
+	if sync.Ahead, sync.Behind, err = git.AheadBehind(ctx, branch, base); err != nil {
+		sync.Error = err.Error()
+	}
+	return sync
+}
+
+// String formats the comparison as shown by status
+func (s syncReport) String() string {
+	switch {
+	case !s.Exists:
+		return "(branch not found)"
+	case s.Error != "":
+		return fmt.Sprintf("(unknown: %s)", s.Error)
+	}
+	return fmt.Sprintf("%d ahead, %d behind", s.Ahead, s.Behind)
+}
@@ -0,0 +1,100 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/spf13/cobra"
+
+	"github.com/gizzahub/gzh-cli-gitflow/internal/gitcmd"
+	"github.com/gizzahub/gzh-cli-gitflow/pkg/config"
+)
+
+// newTrackCmd creates the track subcommand for a flow branch type
+func newTrackCmd(t config.BranchType) *cobra.Command {
+	c := &cobra.Command{
+		Use:   "track <name>",
+		Short: fmt.Sprintf("Check out a %s branch published by someone else", t),
+		Long: fmt.Sprintf(`Fetch a %[1]s branch from the remote and create a local branch
+that tracks it, then switch to it.
+
+The remote is options.remote (default: origin) unless --remote is given.
+
+Example:
+  gz-flow %[1]s track %[2]s
+  gz-flow %[1]s track %[2]s --remote upstream`, t, exampleName(t)),
+		Args: cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return runTrack(t, args)
+		},
+	}
+	c.Flags().StringVar(&remoteName, "remote", "", "Remote to track (default: options.remote)")
+	return c
+}
+
+func runTrack(t config.BranchType, args []string) error {
+	report := beginReport(string(t)+" track", args)
+
+	if err := checkGitRepo(); err != nil {
+		return err
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 60*time.Second)
+	defer cancel()
+
+	git := gitcmd.New()
+	cfg, err := loadConfig()
+	if err != nil {
+		return err
+	}
+
+	// 1. Validate the name and the remote
+	name, err := flowBranchName(ctx, git, cfg, t, args)
+	if err != nil {
+		return err
+	}
+	report.Name = name
+	branch := cfg.Prefix(t) + name
+
+	exists, _ := git.BranchExists(ctx, branch)
+	if exists {
+		return fmt.Errorf("branch '%s' already exists locally\n💡 Use 'gz-flow %s pull %s' to update it", branch, t, name)
+	}
+
+	remote, err := resolveRemote(ctx, git, cfg)
+	if err != nil {
+		return err
+	}
+
+	// 2. Fetch the branch, then create the tracking branch
+	fetch := gitcmd.Step{Op: gitcmd.OpFetch, Branch: branch, Remote: remote}
+	track := gitcmd.Step{Op: gitcmd.OpTrack, Branch: branch, Remote: remote}
+	if dryRun {
+		return printPlan(gitcmd.Plan{fetch, track}, nil)
+	}
+
+	if err := git.Apply(ctx, fetch); err != nil {
+		return fmt.Errorf("failed to fetch '%s/%s': %v\n💡 Check that it was published with 'gz-flow %s publish'", remote, branch, err, t)
+	}
+	report.Completed = append(report.Completed, fetch.String())
+
+	remoteExists, err := git.RemoteBranchExists(ctx, remote, branch)
+	if err != nil {
+		return err
+	}
+	if !remoteExists {
+		return fmt.Errorf("'%s/%s' does not exist\n💡 Check that it was published with 'gz-flow %s publish'", remote, branch, t)
+	}
+
+	if err := git.Apply(ctx, track); err != nil {
+		return fmt.Errorf("failed to create branch: %v", err)
+	}
+	report.Completed = append(report.Completed, track.String())
+	report.Branch = branch
+
+	fmt.Fprintf(ui, "✅ Created '%s' tracking '%s/%s'\n", branch, remote, branch)
+	fmt.Fprintf(ui, "📍 Switched to branch '%s'\n", branch)
+
+	return nil
+}
@@ -0,0 +1,145 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"text/tabwriter"
+	"time"
+
+	"github.com/spf13/cobra"
+
+	"github.com/gizzahub/gzh-cli-gitflow/internal/gitcmd"
+	"github.com/gizzahub/gzh-cli-gitflow/pkg/config"
+)
+
+var whoCmd = &cobra.Command{
+	Use:   "who <path>",
+	Short: "Show which flow branches modify a file",
+	Long: `List every active feature, release and hotfix branch that modifies a
+file or directory, with the lines it adds and deletes.
+
+Each branch is compared with its merge-base with develop, so only the
+branch's own changes count, not what was merged into develop since it
+started. Use it to spot branches working on the same files before they
+conflict at finish time.
+
+Example:
+  gz-flow who src/auth/login.go
+  gz-flow who internal/api`,
+	Args: cobra.ExactArgs(1),
+	RunE: runWho,
+}
+
+// whoReport is the structured output of who
+type whoReport struct {
+	Path     string     `json:"path" yaml:"path"`
+	Branches []whoEntry `json:"branches" yaml:"branches"`
+}
+
+// whoEntry is a flow branch that modifies the path
+type whoEntry struct {
+	Branch  string            `json:"branch" yaml:"branch"`
+	Type    config.BranchType `json:"type" yaml:"type"`
+	Author  string            `json:"author" yaml:"author"`
+	Files   int               `json:"files" yaml:"files"`
+	Added   int               `json:"added" yaml:"added"`
+	Deleted int               `json:"deleted" yaml:"deleted"`
+	Binary  bool              `json:"binary,omitempty" yaml:"binary,omitempty"`
+	Current bool              `json:"current" yaml:"current"`
+}
+
+func init() {
+	rootCmd.AddCommand(whoCmd)
+}
+
+func runWho(cmd *cobra.Command, args []string) error {
+	if err := checkGitRepo(); err != nil {
+		return err
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+	defer cancel()
+
+	git := gitcmd.New()
+	cfg, err := loadConfig()
+	if err != nil {
+		return err
+	}
+
+	path := args[0]
+	develop := cfg.Branches.Develop
+	if exists, _ := git.BranchExists(ctx, develop); !exists {
+		return fmt.Errorf("develop branch '%s' not found\n💡 Run 'gz-flow init' first", develop)
+	}
+
+	// 1. Diff every flow branch against its merge-base with develop
+	report := &whoReport{Path: path, Branches: []whoEntry{}}
+	result = report
+	current, _ := git.CurrentBranch(ctx)
+	for _, t := range config.FlowTypes {
+		infos, err := git.ListBranchInfo(ctx, cfg.Prefix(t))
+		if err != nil {
+			return fmt.Errorf("failed to list %s branches: %v", t, err)
+		}
+		for _, info := range infos {
+			stats, err := git.DiffStat(ctx, develop, info.Name, path)
+			if err != nil {
+				return fmt.Errorf("failed to diff %s: %v", info.Name, err)
+			}
+			if len(stats) == 0 {
+				continue
+			}
+			entry := whoEntry{Branch: info.Name, Type: t, Author: info.Author, Files: len(stats), Current: info.Name == current}
+			for _, s := range stats {
+				entry.Added += s.Added
+				entry.Deleted += s.Deleted
+				entry.Binary = entry.Binary || s.Binary
+			}
+			report.Branches = append(report.Branches, entry)
+		}
+	}
+
+	// 2. Most changed first
+	sort.SliceStable(report.Branches, func(i, j int) bool {
+		a, b := report.Branches[i], report.Branches[j]
+		return a.Added+a.Deleted > b.Added+b.Deleted
+	})
+
+	if len(report.Branches) == 0 {
+		fmt.Fprintf(ui, "📁 %s is not modified in any flow branch\n", path)
+		return nil
+	}
+
+	fmt.Fprintf(ui, "📁 %s modified in:\n", path)
+	w := tabwriter.NewWriter(ui, 0, 0, 2, ' ', 0)
+	for _, e := range report.Branches {
+		fmt.Fprintf(w, "  %s\t%s\t%s%s\n", e.Branch, e.changes(), e.Author, e.marker())
+	}
+	w.Flush()
+
+	if len(report.Branches) > 1 {
+		fmt.Fprintf(ui, "\n⚠️  %s modify this path; expect conflicts when they finish\n", branchCount(len(report.Branches)))
+	}
+	return nil
+}
+
+// changes formats the line counts of the entry
+func (e whoEntry) changes() string {
+	s := fmt.Sprintf("(+%d, -%d", e.Added, e.Deleted)
+	if e.Files > 1 {
+		s += ", " + plural(e.Files, "file")
+	}
+	if e.Binary {
+		s += ", binary"
+	}
+	return s + ")"
+}
+
+// marker flags the checked-out branch
+func (e whoEntry) marker() string {
+	if e.Current {
+		return "  ← current"
+	}
+	return ""
+}
@@ -4,10 +4,15 @@ package gitcmd
 import (
 	"bytes"
 	"context"
+	"errors"
 	"fmt"
+	"os"
 	"os/exec"
+	"path/filepath"
 	"regexp"
+	"strconv"
 	"strings"
+	"time"
 )
 
 // Executor executes git commands safely.
@@ -55,9 +60,16 @@ func validateTagName(tag string) error {
 	return nil
 }
 
-// run executes a git command with the given arguments.
-// This is the ONLY place where exec.Command should be called.
+// run executes a git command with the given arguments and returns trimmed stdout.
 func (e *Executor) run(ctx context.Context, args ...string) (string, error) {
+	out, err := e.output(ctx, args...)
+	return strings.TrimSpace(out), err
+}
+
+// output executes a git command and returns stdout untouched, also when the
+// command fails (some commands report results through their exit code).
+// This is the ONLY place where exec.Command should be called.
+func (e *Executor) output(ctx context.Context, args ...string) (string, error) {
 	cmd := exec.CommandContext(ctx, "git", args...)
 	if e.workDir != "" {
 		cmd.Dir = e.workDir
@@ -67,10 +79,10 @@ func (e *Executor) run(ctx context.Context, args ...string) (string, error) {
 	cmd.Stderr = &stderr
 
 	if err := cmd.Run(); err != nil {
-		return "", fmt.Errorf("git %s: %w: %s", strings.Join(args, " "), err, stderr.String())
+		return stdout.String(), fmt.Errorf("git %s: %w: %s", strings.Join(args, " "), err, stderr.String())
 	}
 
-	return strings.TrimSpace(stdout.String()), nil
+	return stdout.String(), nil
 }
 
 // CurrentBranch returns the current branch name.
@@ -87,6 +99,49 @@ func (e *Executor) IsClean(ctx context.Context) (bool, error) {
 	return out == "", nil
 }
 
+// FileStatus is a single entry of `git status --porcelain`
+type FileStatus struct {
+	Code string `json:"code" yaml:"code"` // two-letter XY status code, e.g. " M", "??"
+	Path string `json:"path" yaml:"path"`
+}
+
+// Status returns the changed and untracked files in the working directory.
+func (e *Executor) Status(ctx context.Context) ([]FileStatus, error) {
+	out, err := e.output(ctx, "status", "--porcelain")
+	if err != nil {
+		return nil, err
+	}
+
+	var files []FileStatus
+	for _, line := range strings.Split(out, "\n") {
+		if len(line) < 4 {
+			continue
+		}
+		files = append(files, FileStatus{Code: line[:2], Path: line[3:]})
+	}
+	return files, nil
+}
+
+// AheadBehind returns how many commits branch is ahead of and behind base.
+func (e *Executor) AheadBehind(ctx context.Context, branch, base string) (int, int, error) {
+	if err := validateBranchName(branch); err != nil {
+		return 0, 0, fmt.Errorf("invalid branch name: %w", err)
+	}
+	if err := validateBranchName(base); err != nil {
+		return 0, 0, fmt.Errorf("invalid branch name: %w", err)
+	}
+	out, err := e.run(ctx, "rev-list", "--left-right", "--count", branch+"..."+base)
+	if err != nil {
+		return 0, 0, err
+	}
+
+	var ahead, behind int
+	if _, err := fmt.Sscanf(out, "%d %d", &ahead, &behind); err != nil {
+		return 0, 0, fmt.Errorf("unexpected rev-list output %q: %w", out, err)
+	}
+	return ahead, behind, nil
+}
+
 // BranchExists checks if a branch exists
 func (e *Executor) BranchExists(ctx context.Context, name string) (bool, error) {
 	if err := validateBranchName(name); err != nil {
@@ -106,6 +161,32 @@ func (e *Executor) BranchExists(ctx context.Context, name string) (bool, error)
 	return true, nil
 }
 
+// CreateBranchFrom creates a new branch pointing at start without switching to it.
+func (e *Executor) CreateBranchFrom(ctx context.Context, branch, start string) error {
+	if err := validateBranchName(branch); err != nil {
+		return fmt.Errorf("invalid branch name: %w", err)
+	}
+	if err := validateBranchName(start); err != nil {
+		return fmt.Errorf("invalid start point: %w", err)
+	}
+	_, err := e.run(ctx, "branch", branch, start)
+	return err
+}
+
+// RemoteDefaultBranch returns the branch that refs/remotes/<remote>/HEAD points at.
+// It returns an empty string if the remote HEAD is not set.
+func (e *Executor) RemoteDefaultBranch(ctx context.Context, remote string) (string, error) {
+	if err := validateBranchName(remote); err != nil {
+		return "", fmt.Errorf("invalid remote name: %w", err)
+	}
+	out, err := e.run(ctx, "symbolic-ref", "--quiet", "--short", "refs/remotes/"+remote+"/HEAD")
+	if err != nil {
+		// symbolic-ref exits non-zero when the ref is missing
+		return "", nil
+	}
+	return strings.TrimPrefix(out, remote+"/"), nil
+}
+
 // Checkout switches to the specified branch.
 func (e *Executor) Checkout(ctx context.Context, branch string) error {
 	if err := validateBranchName(branch); err != nil {
@@ -138,6 +219,16 @@ func (e *Executor) Merge(ctx context.Context, branch string, noFF bool) error {
 	return err
 }
 
+// MergeFastForward merges branch into the current branch only if that is a
+// fast-forward, so no merge commit is created.
+func (e *Executor) MergeFastForward(ctx context.Context, branch string) error {
+	if err := validateBranchName(branch); err != nil {
+		return fmt.Errorf("invalid branch name: %w", err)
+	}
+	_, err := e.run(ctx, "merge", "--ff-only", branch)
+	return err
+}
+
 // DeleteBranch deletes the specified branch.
 func (e *Executor) DeleteBranch(ctx context.Context, name string) error {
 	if err := validateBranchName(name); err != nil {
@@ -147,6 +238,16 @@ func (e *Executor) DeleteBranch(ctx context.Context, name string) error {
 	return err
 }
 
+// ForceDeleteBranch deletes a branch even if it is not merged into HEAD.
+// Callers check that its commits are reachable elsewhere first.
+func (e *Executor) ForceDeleteBranch(ctx context.Context, name string) error {
+	if err := validateBranchName(name); err != nil {
+		return fmt.Errorf("invalid branch name: %w", err)
+	}
+	_, err := e.run(ctx, "branch", "-D", name)
+	return err
+}
+
 // ListBranches returns all branches matching the prefix.
 func (e *Executor) ListBranches(ctx context.Context, prefix string) ([]string, error) {
 	out, err := e.run(ctx, "branch", "--list", prefix+"*")
@@ -170,6 +271,184 @@ func (e *Executor) ListBranches(ctx context.Context, prefix string) ([]string, e
 	return branches, nil
 }
 
+// BranchInfo describes a local branch and its last commit
+type BranchInfo struct {
+	Name       string    `json:"name" yaml:"name"`
+	LastCommit time.Time `json:"last_commit" yaml:"last_commit"`
+	Author     string    `json:"author" yaml:"author"`
+}
+
+// ListBranchInfo returns metadata for all local branches matching the prefix.
+func (e *Executor) ListBranchInfo(ctx context.Context, prefix string) ([]BranchInfo, error) {
+	return e.branchInfo(ctx, "refs/heads/", prefix)
+}
+
+// ListRemoteBranchInfo returns metadata for the remote-tracking branches of a
+// remote matching the prefix. Names are given without the remote.
+func (e *Executor) ListRemoteBranchInfo(ctx context.Context, remote, prefix string) ([]BranchInfo, error) {
+	if err := validateBranchName(remote); err != nil {
+		return nil, fmt.Errorf("invalid remote name: %w", err)
+	}
+	return e.branchInfo(ctx, "refs/remotes/"+remote+"/", prefix)
+}
+
+// branchInfo lists the refs under namespace whose name starts with prefix
+func (e *Executor) branchInfo(ctx context.Context, namespace, prefix string) ([]BranchInfo, error) {
+	pattern := namespace + prefix
+	if prefix != "" && !strings.HasSuffix(prefix, "/") {
+		pattern += "*"
+	}
+	out, err := e.run(ctx, "for-each-ref", "--format=%(refname)%00%(committerdate:unix)%00%(authorname)", pattern)
+	if err != nil {
+		return nil, err
+	}
+
+	infos := []BranchInfo{}
+	if out == "" {
+		return infos, nil
+	}
+	for _, line := range strings.Split(out, "\n") {
+		fields := strings.SplitN(line, "\x00", 3)
+		if len(fields) != 3 {
+			continue
+		}
+		unix, err := strconv.ParseInt(fields[1], 10, 64)
+		if err != nil {
+			return nil, fmt.Errorf("unexpected commit date %q for %s: %w", fields[1], fields[0], err)
+		}
+		infos = append(infos, BranchInfo{
+			Name:       strings.TrimPrefix(fields[0], namespace),
+			LastCommit: time.Unix(unix, 0),
+			Author:     fields[2],
+		})
+	}
+	return infos, nil
+}
+
+// IsMerged returns true if all commits of branch are reachable from into.
+func (e *Executor) IsMerged(ctx context.Context, branch, into string) (bool, error) {
+	if err := validateBranchName(branch); err != nil {
+		return false, fmt.Errorf("invalid branch name: %w", err)
+	}
+	if err := validateBranchName(into); err != nil {
+		return false, fmt.Errorf("invalid branch name: %w", err)
+	}
+	_, err := e.run(ctx, "merge-base", "--is-ancestor", branch, into)
+	if err != nil {
+		// Exit code 1 means "not an ancestor"; anything else is a real error
+		var exitErr *exec.ExitError
+		if errors.As(err, &exitErr) && exitErr.ExitCode() == 1 {
+			return false, nil
+		}
+		return false, err
+	}
+	return true, nil
+}
+
+// IsUnchangedSinceCreated returns true if branch still points at the commit
+// it was created from: its reflog starts with the creation entry and every
+// entry since points at the same commit. A branch with commits of its own
+// has other entries, even after it was merged by fast-forward. Without a
+// reflog the answer is false.
+func (e *Executor) IsUnchangedSinceCreated(ctx context.Context, branch string) (bool, error) {
+	if err := validateBranchName(branch); err != nil {
+		return false, fmt.Errorf("invalid branch name: %w", err)
+	}
+	sha, err := e.RevParse(ctx, branch)
+	if err != nil {
+		return false, err
+	}
+	// Newest entry first
+	entries, err := e.lines(ctx, "reflog", "show", "--format=%H %gs", "refs/heads/"+branch, "--")
+	if err != nil || len(entries) == 0 {
+		return false, err
+	}
+	for _, entry := range entries {
+		if !strings.HasPrefix(entry, sha+" ") {
+			return false, nil
+		}
+	}
+	return strings.HasPrefix(entries[len(entries)-1], sha+" branch: Created from "), nil
+}
+
+// GitDir returns the path of the repository's .git directory.
+func (e *Executor) GitDir(ctx context.Context) (string, error) {
+	dir, err := e.run(ctx, "rev-parse", "--git-dir")
+	if err != nil {
+		return "", err
+	}
+	if !filepath.IsAbs(dir) && e.workDir != "" {
+		dir = filepath.Join(e.workDir, dir)
+	}
+	return dir, nil
+}
+
+// RevParse returns the commit SHA a ref points at.
+func (e *Executor) RevParse(ctx context.Context, ref string) (string, error) {
+	if err := validateBranchName(ref); err != nil {
+		return "", fmt.Errorf("invalid ref: %w", err)
+	}
+	return e.run(ctx, "rev-parse", "--verify", ref+"^{commit}")
+}
+
+// IsMerging returns true if a merge is in progress (MERGE_HEAD exists).
+func (e *Executor) IsMerging(ctx context.Context) (bool, error) {
+	_, err := e.run(ctx, "rev-parse", "-q", "--verify", "MERGE_HEAD")
+	if err != nil {
+		var exitErr *exec.ExitError
+		if errors.As(err, &exitErr) && exitErr.ExitCode() == 1 {
+			return false, nil
+		}
+		return false, err
+	}
+	return true, nil
+}
+
+// UnmergedFiles returns the paths that still have unresolved conflicts.
+func (e *Executor) UnmergedFiles(ctx context.Context) ([]string, error) {
+	out, err := e.run(ctx, "diff", "--name-only", "--diff-filter=U")
+	if err != nil {
+		return nil, err
+	}
+	if out == "" {
+		return []string{}, nil
+	}
+	return strings.Split(out, "\n"), nil
+}
+
+// CommitMerge concludes an in-progress merge using the prepared message.
+func (e *Executor) CommitMerge(ctx context.Context) error {
+	_, err := e.run(ctx, "commit", "--no-edit")
+	return err
+}
+
+// MergeAbort aborts an in-progress merge.
+func (e *Executor) MergeAbort(ctx context.Context) error {
+	_, err := e.run(ctx, "merge", "--abort")
+	return err
+}
+
+// ResetHard resets the current branch, index and working tree to sha.
+func (e *Executor) ResetHard(ctx context.Context, sha string) error {
+	if err := validateBranchName(sha); err != nil {
+		return fmt.Errorf("invalid ref: %w", err)
+	}
+	_, err := e.run(ctx, "reset", "--hard", sha)
+	return err
+}
+
+// SetBranch points a branch that is not checked out at sha, creating it if needed.
+func (e *Executor) SetBranch(ctx context.Context, branch, sha string) error {
+	if err := validateBranchName(branch); err != nil {
+		return fmt.Errorf("invalid branch name: %w", err)
+	}
+	if err := validateBranchName(sha); err != nil {
+		return fmt.Errorf("invalid ref: %w", err)
+	}
+	_, err := e.run(ctx, "branch", "--force", branch, sha)
+	return err
+}
+
 // CreateTag creates an annotated tag at the current HEAD
 func (e *Executor) CreateTag(ctx context.Context, tag, message string) error {
 	if err := validateTagName(tag); err != nil {
@@ -211,3 +490,407 @@ func (e *Executor) TagExists(ctx context.Context, tag string) (bool, error) {
 
 	return true, nil
 }
+
+// DeleteTag deletes a local tag
+func (e *Executor) DeleteTag(ctx context.Context, tag string) error {
+	if err := validateTagName(tag); err != nil {
+		return err
+	}
+	_, err := e.run(ctx, "tag", "-d", tag)
+	return err
+}
+
+// Remotes returns the names of the configured remotes
+func (e *Executor) Remotes(ctx context.Context) ([]string, error) {
+	out, err := e.run(ctx, "remote")
+	if err != nil {
+		return nil, err
+	}
+	if out == "" {
+		return []string{}, nil
+	}
+	return strings.Split(out, "\n"), nil
+}
+
+// RemoteExists checks if a remote is configured
+func (e *Executor) RemoteExists(ctx context.Context, remote string) (bool, error) {
+	if err := validateBranchName(remote); err != nil {
+		return false, fmt.Errorf("invalid remote name: %w", err)
+	}
+	remotes, err := e.Remotes(ctx)
+	if err != nil {
+		return false, err
+	}
+	for _, r := range remotes {
+		if r == remote {
+			return true, nil
+		}
+	}
+	return false, nil
+}
+
+// Push pushes a branch to a remote, optionally setting it as the upstream
+func (e *Executor) Push(ctx context.Context, remote, branch string, setUpstream bool) error {
+	if err := validateBranchName(remote); err != nil {
+		return fmt.Errorf("invalid remote name: %w", err)
+	}
+	if err := validateBranchName(branch); err != nil {
+		return fmt.Errorf("invalid branch name: %w", err)
+	}
+	args := []string{"push"}
+	if setUpstream {
+		args = append(args, "--set-upstream")
+	}
+	args = append(args, remote, branch)
+	_, err := e.run(ctx, args...)
+	return err
+}
+
+// PushTag pushes a single tag to a remote
+func (e *Executor) PushTag(ctx context.Context, remote, tag string) error {
+	if err := validateBranchName(remote); err != nil {
+		return fmt.Errorf("invalid remote name: %w", err)
+	}
+	if err := validateTagName(tag); err != nil {
+		return fmt.Errorf("invalid tag name: %w", err)
+	}
+	_, err := e.run(ctx, "push", remote, "refs/tags/"+tag)
+	return err
+}
+
+// DeleteRemoteBranch deletes a branch on a remote
+func (e *Executor) DeleteRemoteBranch(ctx context.Context, remote, branch string) error {
+	if err := validateBranchName(remote); err != nil {
+		return fmt.Errorf("invalid remote name: %w", err)
+	}
+	if err := validateBranchName(branch); err != nil {
+		return fmt.Errorf("invalid branch name: %w", err)
+	}
+	_, err := e.run(ctx, "push", remote, "--delete", branch)
+	return err
+}
+
+// Upstream returns the upstream of a branch (e.g. "origin/feature/x"),
+// or an empty string if none is set.
+func (e *Executor) Upstream(ctx context.Context, branch string) (string, error) {
+	if err := validateBranchName(branch); err != nil {
+		return "", fmt.Errorf("invalid branch name: %w", err)
+	}
+	out, err := e.run(ctx, "for-each-ref", "--format=%(upstream:short)", "refs/heads/"+branch)
+	if err != nil {
+		return "", err
+	}
+	return out, nil
+}
+
+// Fetch fetches from a remote, limited to the given branches if any.
+// Fetching a branch by name also updates its remote-tracking ref.
+func (e *Executor) Fetch(ctx context.Context, remote string, branches ...string) error {
+	if err := validateBranchName(remote); err != nil {
+		return fmt.Errorf("invalid remote name: %w", err)
+	}
+	for _, b := range branches {
+		if err := validateBranchName(b); err != nil {
+			return fmt.Errorf("invalid branch name: %w", err)
+		}
+	}
+	args := append([]string{"fetch", remote}, branches...)
+	_, err := e.run(ctx, args...)
+	return err
+}
+
+// RemoteBranchExists checks if the remote-tracking ref <remote>/<branch> exists
+func (e *Executor) RemoteBranchExists(ctx context.Context, remote, branch string) (bool, error) {
+	if err := validateBranchName(remote); err != nil {
+		return false, fmt.Errorf("invalid remote name: %w", err)
+	}
+	if err := validateBranchName(branch); err != nil {
+		return false, fmt.Errorf("invalid branch name: %w", err)
+	}
+	_, err := e.run(ctx, "rev-parse", "--verify", "--quiet", "refs/remotes/"+remote+"/"+branch)
+	if err != nil {
+		var exitErr *exec.ExitError
+		if errors.As(err, &exitErr) && exitErr.ExitCode() == 1 {
+			return false, nil
+		}
+		return false, err
+	}
+	return true, nil
+}
+
+// TrackBranch creates a local branch from <remote>/<branch>, sets it as the
+// upstream and switches to it
+func (e *Executor) TrackBranch(ctx context.Context, remote, branch string) error {
+	if err := validateBranchName(remote); err != nil {
+		return fmt.Errorf("invalid remote name: %w", err)
+	}
+	if err := validateBranchName(branch); err != nil {
+		return fmt.Errorf("invalid branch name: %w", err)
+	}
+	_, err := e.run(ctx, "checkout", "--track", "-b", branch, remote+"/"+branch)
+	return err
+}
+
+// Rebase rebases the current branch onto the given ref
+func (e *Executor) Rebase(ctx context.Context, onto string) error {
+	if err := validateBranchName(onto); err != nil {
+		return fmt.Errorf("invalid ref: %w", err)
+	}
+	_, err := e.run(ctx, "rebase", onto)
+	return err
+}
+
+// IsRebasing returns true if a rebase is in progress
+func (e *Executor) IsRebasing(ctx context.Context) (bool, error) {
+	gitDir, err := e.GitDir(ctx)
+	if err != nil {
+		return false, err
+	}
+	for _, dir := range []string{"rebase-merge", "rebase-apply"} {
+		if _, err := os.Stat(filepath.Join(gitDir, dir)); err == nil {
+			return true, nil
+		}
+	}
+	return false, nil
+}
+
+// RebaseAbort aborts an in-progress rebase
+func (e *Executor) RebaseAbort(ctx context.Context) error {
+	_, err := e.run(ctx, "rebase", "--abort")
+	return err
+}
+
+// MergeConflicts simulates merging branch into into without touching the
+// working tree, the index or any ref, and returns the paths that would
+// conflict. Requires git 2.38 or newer (merge-tree --write-tree).
+func (e *Executor) MergeConflicts(ctx context.Context, branch, into string) ([]string, error) {
+	if err := validateBranchName(branch); err != nil {
+		return nil, fmt.Errorf("invalid branch name: %w", err)
+	}
+	if err := validateBranchName(into); err != nil {
+		return nil, fmt.Errorf("invalid branch name: %w", err)
+	}
+
+	out, err := e.output(ctx, "merge-tree", "--write-tree", "--name-only", "--no-messages", into, branch)
+	if err == nil {
+		return nil, nil
+	}
+	// Conflicts exit with 1 and print the tree OID, then one line per
+	// conflicted path. Invalid revisions also exit with 1, but print nothing.
+	var exitErr *exec.ExitError
+	if !errors.As(err, &exitErr) || exitErr.ExitCode() != 1 || out == "" {
+		return nil, err
+	}
+
+	var files []string
+	seen := map[string]bool{}
+	for _, line := range strings.Split(out, "\n")[1:] {
+		if line == "" {
+			break
+		}
+		if !seen[line] {
+			seen[line] = true
+			files = append(files, line)
+		}
+	}
+	return files, nil
+}
+
+// InProgress returns the kind of multi-step git operation waiting to be
+// continued or aborted: "rebase", "merge", "cherry-pick" or "revert", or an
+// empty string if there is none.
+func (e *Executor) InProgress(ctx context.Context) (string, error) {
+	gitDir, err := e.GitDir(ctx)
+	if err != nil {
+		return "", err
+	}
+	markers := []struct{ path, op string }{
+		{"rebase-merge", "rebase"},
+		{"rebase-apply", "rebase"},
+		{"MERGE_HEAD", "merge"},
+		{"CHERRY_PICK_HEAD", "cherry-pick"},
+		{"REVERT_HEAD", "revert"},
+	}
+	for _, m := range markers {
+		if _, err := os.Stat(filepath.Join(gitDir, m.path)); err == nil {
+			return m.op, nil
+		}
+	}
+	return "", nil
+}
+
+// ModifiedFiles returns the tracked files with staged or unstaged changes.
+func (e *Executor) ModifiedFiles(ctx context.Context) ([]string, error) {
+	return e.lines(ctx, "diff", "HEAD", "--name-only")
+}
+
+// ChangedFiles returns the files branch changes since its merge-base with base.
+func (e *Executor) ChangedFiles(ctx context.Context, base, branch string) ([]string, error) {
+	if err := validateBranchName(base); err != nil {
+		return nil, fmt.Errorf("invalid branch name: %w", err)
+	}
+	if err := validateBranchName(branch); err != nil {
+		return nil, fmt.Errorf("invalid branch name: %w", err)
+	}
+	return e.lines(ctx, "diff", "--name-only", "--no-renames", base+"..."+branch)
+}
+
+// FileStat is the number of lines a branch adds to and deletes from a file
+type FileStat struct {
+	Path    string `json:"path" yaml:"path"`
+	Added   int    `json:"added" yaml:"added"`
+	Deleted int    `json:"deleted" yaml:"deleted"`
+	Binary  bool   `json:"binary,omitempty" yaml:"binary,omitempty"`
+}
+
+// DiffStat returns the files branch changes since its merge-base with base,
+// limited to the given pathspecs if any. Returned paths are relative to the
+// repository root.
+func (e *Executor) DiffStat(ctx context.Context, base, branch string, paths ...string) ([]FileStat, error) {
+	if err := validateBranchName(base); err != nil {
+		return nil, fmt.Errorf("invalid branch name: %w", err)
+	}
+	if err := validateBranchName(branch); err != nil {
+		return nil, fmt.Errorf("invalid branch name: %w", err)
+	}
+	args := append([]string{"diff", "--numstat", "-z", "--no-renames", base + "..." + branch, "--"}, paths...)
+	out, err := e.output(ctx, args...)
+	if err != nil {
+		return nil, err
+	}
+
+	// Each record is "<added>\t<deleted>\t<path>\0"; binary files show "-" counts
+	stats := []FileStat{}
+	for _, record := range strings.Split(out, "\x00") {
+		fields := strings.SplitN(record, "\t", 3)
+		if len(fields) != 3 {
+			continue
+		}
+		stat := FileStat{Path: fields[2]}
+		if fields[0] == "-" {
+			stat.Binary = true
+		} else {
+			stat.Added, _ = strconv.Atoi(fields[0])
+			stat.Deleted, _ = strconv.Atoi(fields[1])
+		}
+		stats = append(stats, stat)
+	}
+	return stats, nil
+}
+
+// UntrackedFiles returns the files git does not track and does not ignore.
+func (e *Executor) UntrackedFiles(ctx context.Context) ([]string, error) {
+	return e.lines(ctx, "ls-files", "--others", "--exclude-standard")
+}
+
+// DirtySubmodules returns the submodules whose checked-out commit differs from
+// the one recorded, or that contain modified or untracked files.
+func (e *Executor) DirtySubmodules(ctx context.Context) ([]string, error) {
+	out, err := e.output(ctx, "status", "--porcelain=v2", "--ignore-submodules=none")
+	if err != nil {
+		return nil, err
+	}
+
+	// Changed entries: "1 XY <sub> <mH> <mI> <mW> <hH> <hI> <path>", where
+	// <sub> is "N..." for files and "S<c><m><u>" for submodules
+	var dirty []string
+	for _, line := range strings.Split(out, "\n") {
+		fields := strings.SplitN(line, " ", 9)
+		if len(fields) < 9 || fields[0] != "1" {
+			continue
+		}
+		if sub := fields[2]; strings.HasPrefix(sub, "S") && sub != "S..." {
+			dirty = append(dirty, fields[8])
+		}
+	}
+	return dirty, nil
+}
+
+// IsShallow returns true if the repository is a shallow clone.
+func (e *Executor) IsShallow(ctx context.Context) (bool, error) {
+	out, err := e.run(ctx, "rev-parse", "--is-shallow-repository")
+	if err != nil {
+		return false, err
+	}
+	return out == "true", nil
+}
+
+// ConfigValue returns the effective value of a git config key, or an empty
+// string if it is not set.
+func (e *Executor) ConfigValue(ctx context.Context, key string) (string, error) {
+	if err := validateBranchName(key); err != nil {
+		return "", fmt.Errorf("invalid config key: %w", err)
+	}
+	out, err := e.run(ctx, "config", "--get", key)
+	if err != nil {
+		var exitErr *exec.ExitError
+		if errors.As(err, &exitErr) && exitErr.ExitCode() == 1 {
+			return "", nil
+		}
+		return "", err
+	}
+	return out, nil
+}
+
+// StashEntry is an entry of the stash list
+type StashEntry struct {
+	Ref     string // e.g. stash@{0}
+	Branch  string // branch the entry was made on
+	Message string
+}
+
+// stashRefPattern matches the stash entries StashPop accepts
+var stashRefPattern = regexp.MustCompile(`^stash@\{[0-9]+\}$`)
+
+// StashPush saves the uncommitted changes, untracked files included, as a
+// new stash entry and cleans the working tree.
+func (e *Executor) StashPush(ctx context.Context, message string) error {
+	_, err := e.run(ctx, "stash", "push", "--include-untracked", "-m", message)
+	return err
+}
+
+// StashList returns the stash entries, newest first.
+func (e *Executor) StashList(ctx context.Context) ([]StashEntry, error) {
+	lines, err := e.lines(ctx, "stash", "list", "--format=%gd%x00%gs")
+	if err != nil {
+		return nil, err
+	}
+
+	// The subject is "On <branch>: <message>", or "WIP on <branch>: ..."
+	// for entries made without a message
+	entries := make([]StashEntry, 0, len(lines))
+	for _, line := range lines {
+		ref, subject, ok := strings.Cut(line, "\x00")
+		if !ok {
+			continue
+		}
+		entry := StashEntry{Ref: ref, Message: subject}
+		if on, message, ok := strings.Cut(subject, ": "); ok {
+			entry.Branch = on[strings.LastIndex(on, " ")+1:]
+			entry.Message = message
+		}
+		entries = append(entries, entry)
+	}
+	return entries, nil
+}
+
+// StashPop applies a stash entry to the working tree and drops it.
+func (e *Executor) StashPop(ctx context.Context, ref string) error {
+	if !stashRefPattern.MatchString(ref) {
+		return fmt.Errorf("invalid stash entry %q", ref)
+	}
+	_, err := e.run(ctx, "stash", "pop", ref)
+	return err
+}
+
+// lines runs a git command and splits its output into non-empty lines
+func (e *Executor) lines(ctx context.Context, args ...string) ([]string, error) {
+	out, err := e.run(ctx, args...)
+	if err != nil {
+		return nil, err
+	}
+	if out == "" {
+		return []string{}, nil
+	}
+	return strings.Split(out, "\n"), nil
+}
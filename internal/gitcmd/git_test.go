@@ -3,10 +3,42 @@ package gitcmd
 
 import (
 	"context"
+	"os"
+	"os/exec"
+	"path/filepath"
 	"strings"
 	"testing"
 )
 
+// newTestRepo creates a temporary repository with one commit on master
+func newTestRepo(t *testing.T) (*Executor, string) {
+	t.Helper()
+
+	dir := t.TempDir()
+	gitInDir(t, dir, "init", "--initial-branch=master")
+	gitInDir(t, dir, "config", "user.email", "test@test.com")
+	gitInDir(t, dir, "config", "user.name", "Test")
+	if err := os.WriteFile(filepath.Join(dir, "README.md"), []byte("# Test"), 0o644); err != nil {
+		t.Fatalf("Failed to create README: %v", err)
+	}
+	gitInDir(t, dir, "add", ".")
+	gitInDir(t, dir, "commit", "-m", "Initial commit")
+
+	return New().WithWorkDir(dir), dir
+}
+
+// gitInDir runs a raw git command for test setup
+func gitInDir(t *testing.T, dir string, args ...string) string {
+	t.Helper()
+	cmd := exec.Command("git", args...)
+	cmd.Dir = dir
+	out, err := cmd.CombinedOutput()
+	if err != nil {
+		t.Fatalf("git %v failed: %v\n%s", args, err, out)
+	}
+	return strings.TrimSpace(string(out))
+}
+
 func TestRun_CurrentBranch(t *testing.T) {
 	ctx := context.Background()
 	git := New()
@@ -381,3 +413,891 @@ func TestExecutor_TagExists(t *testing.T) {
 		})
 	}
 }
+
+func TestCreateBranchFrom(t *testing.T) {
+	ctx := context.Background()
+	git, _ := newTestRepo(t)
+
+	if err := git.CreateBranchFrom(ctx, "develop", "master"); err != nil {
+		t.Fatalf("CreateBranchFrom failed: %v", err)
+	}
+
+	exists, err := git.BranchExists(ctx, "develop")
+	if err != nil || !exists {
+		t.Fatalf("develop should exist, exists=%v err=%v", exists, err)
+	}
+
+	// Should not switch branches
+	current, _ := git.CurrentBranch(ctx)
+	if current != "master" {
+		t.Errorf("CreateBranchFrom should not switch branches, on %q", current)
+	}
+
+	if err := git.CreateBranchFrom(ctx, "-invalid", "master"); err == nil {
+		t.Error("CreateBranchFrom should fail with invalid branch name")
+	}
+}
+
+func TestRemoteDefaultBranch(t *testing.T) {
+	ctx := context.Background()
+	git, dir := newTestRepo(t)
+
+	head, err := git.RemoteDefaultBranch(ctx, "origin")
+	if err != nil {
+		t.Fatalf("RemoteDefaultBranch failed: %v", err)
+	}
+	if head != "" {
+		t.Errorf("Expected empty result without remote, got %q", head)
+	}
+
+	gitInDir(t, dir, "update-ref", "refs/remotes/origin/main", "HEAD")
+	gitInDir(t, dir, "symbolic-ref", "refs/remotes/origin/HEAD", "refs/remotes/origin/main")
+
+	head, err = git.RemoteDefaultBranch(ctx, "origin")
+	if err != nil {
+		t.Fatalf("RemoteDefaultBranch failed: %v", err)
+	}
+	if head != "main" {
+		t.Errorf("Expected 'main', got %q", head)
+	}
+}
+
+func TestStatus(t *testing.T) {
+	ctx := context.Background()
+	git, dir := newTestRepo(t)
+
+	files, err := git.Status(ctx)
+	if err != nil {
+		t.Fatalf("Status failed: %v", err)
+	}
+	if len(files) != 0 {
+		t.Errorf("Expected clean status, got %v", files)
+	}
+
+	if err := os.WriteFile(filepath.Join(dir, "README.md"), []byte("changed"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(filepath.Join(dir, "new.txt"), []byte("new"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+
+	files, err = git.Status(ctx)
+	if err != nil {
+		t.Fatalf("Status failed: %v", err)
+	}
+	want := []FileStatus{{Code: " M", Path: "README.md"}, {Code: "??", Path: "new.txt"}}
+	if len(files) != len(want) {
+		t.Fatalf("Status = %v, want %v", files, want)
+	}
+	for i := range want {
+		if files[i] != want[i] {
+			t.Errorf("Status[%d] = %+v, want %+v", i, files[i], want[i])
+		}
+	}
+}
+
+func TestAheadBehind(t *testing.T) {
+	ctx := context.Background()
+	git, dir := newTestRepo(t)
+
+	gitInDir(t, dir, "checkout", "-b", "feature/x")
+	gitInDir(t, dir, "commit", "--allow-empty", "-m", "one")
+	gitInDir(t, dir, "commit", "--allow-empty", "-m", "two")
+	gitInDir(t, dir, "checkout", "master")
+	gitInDir(t, dir, "commit", "--allow-empty", "-m", "three")
+
+	ahead, behind, err := git.AheadBehind(ctx, "feature/x", "master")
+	if err != nil {
+		t.Fatalf("AheadBehind failed: %v", err)
+	}
+	if ahead != 2 || behind != 1 {
+		t.Errorf("AheadBehind = (%d, %d), want (2, 1)", ahead, behind)
+	}
+
+	if _, _, err := git.AheadBehind(ctx, "-x", "master"); err == nil {
+		t.Error("AheadBehind should fail with invalid branch name")
+	}
+}
+
+func TestListBranchInfo(t *testing.T) {
+	ctx := context.Background()
+	git, dir := newTestRepo(t)
+
+	gitInDir(t, dir, "branch", "feature/a")
+	gitInDir(t, dir, "branch", "feature/nested/b")
+	gitInDir(t, dir, "branch", "other")
+
+	infos, err := git.ListBranchInfo(ctx, "feature/")
+	if err != nil {
+		t.Fatalf("ListBranchInfo failed: %v", err)
+	}
+	if len(infos) != 2 {
+		t.Fatalf("Expected 2 feature branches, got %v", infos)
+	}
+	if infos[0].Name != "feature/a" || infos[1].Name != "feature/nested/b" {
+		t.Errorf("Unexpected branches: %v", infos)
+	}
+	if infos[0].Author != "Test" {
+		t.Errorf("Author = %q, want Test", infos[0].Author)
+	}
+	if infos[0].LastCommit.IsZero() {
+		t.Error("LastCommit should be set")
+	}
+
+	infos, err = git.ListBranchInfo(ctx, "nonexistent-prefix-")
+	if err != nil {
+		t.Fatalf("ListBranchInfo failed: %v", err)
+	}
+	if len(infos) != 0 {
+		t.Errorf("Expected no branches, got %v", infos)
+	}
+}
+
+func TestIsMerged(t *testing.T) {
+	ctx := context.Background()
+	git, dir := newTestRepo(t)
+
+	gitInDir(t, dir, "branch", "merged")
+	gitInDir(t, dir, "checkout", "-b", "unmerged")
+	gitInDir(t, dir, "commit", "--allow-empty", "-m", "work")
+	gitInDir(t, dir, "checkout", "master")
+
+	merged, err := git.IsMerged(ctx, "merged", "master")
+	if err != nil || !merged {
+		t.Errorf("IsMerged(merged) = %v, %v; want true", merged, err)
+	}
+
+	merged, err = git.IsMerged(ctx, "unmerged", "master")
+	if err != nil || merged {
+		t.Errorf("IsMerged(unmerged) = %v, %v; want false", merged, err)
+	}
+
+	if _, err := git.IsMerged(ctx, "missing-branch", "master"); err == nil {
+		t.Error("IsMerged should fail for missing branch")
+	}
+}
+
+func TestIsUnchangedSinceCreated(t *testing.T) {
+	ctx := context.Background()
+	git, dir := newTestRepo(t)
+
+	// fresh and renamed are never committed to; ff and done are merged
+	gitInDir(t, dir, "branch", "fresh")
+	gitInDir(t, dir, "branch", "old-name")
+	gitInDir(t, dir, "branch", "-m", "old-name", "renamed")
+	gitInDir(t, dir, "checkout", "-b", "ff")
+	gitInDir(t, dir, "commit", "--allow-empty", "-m", "Fast-forwarded work")
+	gitInDir(t, dir, "checkout", "-b", "done", "master")
+	gitInDir(t, dir, "commit", "--allow-empty", "-m", "Done work")
+	gitInDir(t, dir, "checkout", "master")
+	gitInDir(t, dir, "merge", "--ff-only", "ff")
+	gitInDir(t, dir, "merge", "--no-ff", "done", "-m", "Merge done")
+	gitInDir(t, dir, "update-ref", "refs/heads/no-reflog", "HEAD")
+	gitInDir(t, dir, "reflog", "expire", "--expire=now", "refs/heads/no-reflog")
+
+	for branch, want := range map[string]bool{"fresh": true, "renamed": true, "ff": false, "done": false, "no-reflog": false} {
+		got, err := git.IsUnchangedSinceCreated(ctx, branch)
+		if err != nil || got != want {
+			t.Errorf("IsUnchangedSinceCreated(%s) = %v, %v; want %v", branch, got, err, want)
+		}
+	}
+	if _, err := git.IsUnchangedSinceCreated(ctx, "missing"); err == nil {
+		t.Error("IsUnchangedSinceCreated should fail for a missing branch")
+	}
+}
+
+func TestRevParseAndSetBranch(t *testing.T) {
+	ctx := context.Background()
+	git, dir := newTestRepo(t)
+
+	first, err := git.RevParse(ctx, "master")
+	if err != nil {
+		t.Fatalf("RevParse failed: %v", err)
+	}
+	if first != gitInDir(t, dir, "rev-parse", "master") {
+		t.Errorf("RevParse(master) = %s", first)
+	}
+
+	gitInDir(t, dir, "branch", "other")
+	gitInDir(t, dir, "commit", "--allow-empty", "-m", "Second")
+
+	// Move a branch that is not checked out
+	second, _ := git.RevParse(ctx, "master")
+	if err := git.SetBranch(ctx, "other", second); err != nil {
+		t.Fatalf("SetBranch failed: %v", err)
+	}
+	if got, _ := git.RevParse(ctx, "other"); got != second {
+		t.Errorf("other = %s, want %s", got, second)
+	}
+
+	// Reset the checked out branch
+	if err := git.ResetHard(ctx, first); err != nil {
+		t.Fatalf("ResetHard failed: %v", err)
+	}
+	if got, _ := git.RevParse(ctx, "master"); got != first {
+		t.Errorf("master = %s, want %s", got, first)
+	}
+
+	if _, err := git.RevParse(ctx, "missing"); err == nil {
+		t.Error("RevParse should fail for a missing ref")
+	}
+}
+
+func TestMergeConflictLifecycle(t *testing.T) {
+	ctx := context.Background()
+	git, dir := newTestRepo(t)
+
+	gitInDir(t, dir, "checkout", "-b", "topic")
+	if err := os.WriteFile(filepath.Join(dir, "README.md"), []byte("topic"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	gitInDir(t, dir, "commit", "-am", "Topic change")
+	gitInDir(t, dir, "checkout", "master")
+	if err := os.WriteFile(filepath.Join(dir, "README.md"), []byte("master"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	gitInDir(t, dir, "commit", "-am", "Master change")
+
+	if merging, err := git.IsMerging(ctx); err != nil || merging {
+		t.Fatalf("IsMerging before merge = %v, %v", merging, err)
+	}
+	if err := git.Merge(ctx, "topic", true); err == nil {
+		t.Fatal("Merge should fail with a conflict")
+	}
+	if merging, err := git.IsMerging(ctx); err != nil || !merging {
+		t.Fatalf("IsMerging during conflict = %v, %v", merging, err)
+	}
+	files, err := git.UnmergedFiles(ctx)
+	if err != nil {
+		t.Fatalf("UnmergedFiles failed: %v", err)
+	}
+	if len(files) != 1 || files[0] != "README.md" {
+		t.Errorf("UnmergedFiles = %v, want [README.md]", files)
+	}
+
+	if err := git.MergeAbort(ctx); err != nil {
+		t.Fatalf("MergeAbort failed: %v", err)
+	}
+	if merging, _ := git.IsMerging(ctx); merging {
+		t.Error("merge should be aborted")
+	}
+
+	// Resolve and conclude the merge
+	_ = git.Merge(ctx, "topic", true)
+	if err := os.WriteFile(filepath.Join(dir, "README.md"), []byte("resolved"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	gitInDir(t, dir, "add", "README.md")
+	if err := git.CommitMerge(ctx); err != nil {
+		t.Fatalf("CommitMerge failed: %v", err)
+	}
+	if merged, _ := git.IsMerged(ctx, "topic", "master"); !merged {
+		t.Error("topic should be merged into master")
+	}
+}
+
+func TestApplySteps(t *testing.T) {
+	ctx := context.Background()
+	git, dir := newTestRepo(t)
+	gitInDir(t, dir, "checkout", "-b", "topic")
+	gitInDir(t, dir, "commit", "--allow-empty", "-m", "Topic work")
+
+	steps := []Step{
+		{Op: OpCheckout, Branch: "master"},
+		{Op: OpMerge, Branch: "topic", Into: "master", NoFF: true},
+		{Op: OpTag, Tag: "v1.0.0", Message: "Release 1.0.0"},
+		{Op: OpDeleteBranch, Branch: "topic"},
+	}
+	for _, step := range steps {
+		if err := git.Apply(ctx, step); err != nil {
+			t.Fatalf("Apply(%s) failed: %v", step, err)
+		}
+	}
+
+	if exists, _ := git.TagExists(ctx, "v1.0.0"); !exists {
+		t.Error("tag v1.0.0 should exist")
+	}
+	if exists, _ := git.BranchExists(ctx, "topic"); exists {
+		t.Error("topic should be deleted")
+	}
+
+	if err := git.DeleteTag(ctx, "v1.0.0"); err != nil {
+		t.Fatalf("DeleteTag failed: %v", err)
+	}
+	if exists, _ := git.TagExists(ctx, "v1.0.0"); exists {
+		t.Error("tag v1.0.0 should be deleted")
+	}
+
+	if got := steps[1].String(); got != "git merge --no-ff topic" {
+		t.Errorf("String() = %q", got)
+	}
+}
+
+func TestApplyFastForwardMerge(t *testing.T) {
+	ctx := context.Background()
+	git, dir := newTestRepo(t)
+	gitInDir(t, dir, "checkout", "-b", "topic")
+	gitInDir(t, dir, "commit", "--allow-empty", "-m", "Topic work")
+	gitInDir(t, dir, "checkout", "-b", "diverged", "master")
+	gitInDir(t, dir, "commit", "--allow-empty", "-m", "Other work")
+	gitInDir(t, dir, "checkout", "master")
+
+	step := Step{Op: OpMerge, Branch: "topic", Into: "master", FFOnly: true}
+	if got := step.String(); got != "git merge --ff-only topic" {
+		t.Errorf("String() = %q", got)
+	}
+	if err := git.Apply(ctx, step); err != nil {
+		t.Fatalf("Apply(%s) failed: %v", step, err)
+	}
+	if gitInDir(t, dir, "rev-parse", "master") != gitInDir(t, dir, "rev-parse", "topic") {
+		t.Error("master should be fast-forwarded to topic")
+	}
+
+	// A merge that needs a merge commit is refused
+	if err := git.Apply(ctx, Step{Op: OpMerge, Branch: "diverged", Into: "master", FFOnly: true}); err == nil {
+		t.Error("Apply should refuse a merge that is not a fast-forward")
+	}
+}
+
+func TestGitDir(t *testing.T) {
+	git, dir := newTestRepo(t)
+
+	gitDir, err := git.GitDir(context.Background())
+	if err != nil {
+		t.Fatalf("GitDir failed: %v", err)
+	}
+	if gitDir != filepath.Join(dir, ".git") {
+		t.Errorf("GitDir = %s, want %s", gitDir, filepath.Join(dir, ".git"))
+	}
+}
+
+func TestTransactionRollback(t *testing.T) {
+	ctx := context.Background()
+	git, dir := newTestRepo(t)
+	gitInDir(t, dir, "branch", "develop")
+	gitInDir(t, dir, "checkout", "-b", "release/1.0.0")
+	gitInDir(t, dir, "commit", "--allow-empty", "-m", "Release work")
+
+	tx, err := git.Begin(ctx, "master", "develop", "", "master")
+	if err != nil {
+		t.Fatalf("Begin failed: %v", err)
+	}
+	if tx.OriginalBranch != "release/1.0.0" || len(tx.OriginalRefs) != 2 {
+		t.Fatalf("Begin recorded %+v", tx)
+	}
+	masterBefore := tx.OriginalRefs["master"]
+	developBefore := tx.OriginalRefs["develop"]
+
+	steps := []Step{
+		{Op: OpCheckout, Branch: "master"},
+		{Op: OpMerge, Branch: "release/1.0.0", Into: "master", NoFF: true},
+		{Op: OpTag, Tag: "v1.0.0", Message: "Release 1.0.0"},
+		{Op: OpCheckout, Branch: "develop"},
+		{Op: OpMerge, Branch: "release/1.0.0", Into: "develop", NoFF: true},
+	}
+	for _, step := range steps {
+		if err := git.Apply(ctx, step); err != nil {
+			t.Fatalf("Apply(%s) failed: %v", step, err)
+		}
+		tx.Track(step)
+	}
+
+	restored, err := git.Rollback(ctx, tx)
+	if err != nil {
+		t.Fatalf("Rollback failed: %v", err)
+	}
+	if strings.Join(restored, ",") != "develop,master" {
+		t.Errorf("restored = %v", restored)
+	}
+	if got, _ := git.RevParse(ctx, "master"); got != masterBefore {
+		t.Errorf("master = %s, want %s", got, masterBefore)
+	}
+	if got, _ := git.RevParse(ctx, "develop"); got != developBefore {
+		t.Errorf("develop = %s, want %s", got, developBefore)
+	}
+	if exists, _ := git.TagExists(ctx, "v1.0.0"); exists {
+		t.Error("tag v1.0.0 should be deleted")
+	}
+	if current, _ := git.CurrentBranch(ctx); current != "release/1.0.0" {
+		t.Errorf("current branch = %s, want release/1.0.0", current)
+	}
+}
+
+func TestTransactionRollbackKeepsUncommittedChanges(t *testing.T) {
+	ctx := context.Background()
+	git, dir := newTestRepo(t)
+	gitInDir(t, dir, "checkout", "-b", "feature/x")
+	gitInDir(t, dir, "commit", "--allow-empty", "-m", "Feature work")
+
+	tx, err := git.Begin(ctx, "master")
+	if err != nil {
+		t.Fatalf("Begin failed: %v", err)
+	}
+	masterBefore := tx.OriginalRefs["master"]
+	for _, step := range []Step{
+		{Op: OpCheckout, Branch: "master"},
+		{Op: OpMerge, Branch: "feature/x", Into: "master", NoFF: true},
+	} {
+		if err := git.Apply(ctx, step); err != nil {
+			t.Fatalf("Apply(%s) failed: %v", step, err)
+		}
+	}
+
+	// Uncommitted changes carried onto master survive its reset
+	readme := filepath.Join(dir, "README.md")
+	if err := os.WriteFile(readme, []byte("local edit\n"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := git.Rollback(ctx, tx); err != nil {
+		t.Fatalf("Rollback failed: %v", err)
+	}
+	if got, _ := git.RevParse(ctx, "master"); got != masterBefore {
+		t.Errorf("master = %s, want %s", got, masterBefore)
+	}
+	if current, _ := git.CurrentBranch(ctx); current != "feature/x" {
+		t.Errorf("current branch = %s, want feature/x", current)
+	}
+	if data, _ := os.ReadFile(readme); string(data) != "local edit\n" {
+		t.Errorf("README.md = %q, the uncommitted change was lost", data)
+	}
+	if entries, _ := git.StashList(ctx); len(entries) != 0 {
+		t.Errorf("the stash should be empty, got %+v", entries)
+	}
+}
+
+func TestPlan(t *testing.T) {
+	ctx := context.Background()
+	git, dir := newTestRepo(t)
+
+	plan := Plan{
+		{Op: OpCreateBranch, Branch: "develop"},
+		{Op: OpCheckout, Branch: "master"},
+	}
+	want := "  1. git checkout -b develop\n  2. git checkout master\n"
+	if got := plan.String(); got != want {
+		t.Errorf("String() = %q, want %q", got, want)
+	}
+
+	if err := git.Execute(ctx, plan); err != nil {
+		t.Fatalf("Execute failed: %v", err)
+	}
+	if got := gitInDir(t, dir, "branch", "--show-current"); got != "master" {
+		t.Errorf("current branch = %s, want master", got)
+	}
+
+	err := git.Execute(ctx, Plan{{Op: OpCheckout, Branch: "missing"}})
+	if err == nil || !strings.Contains(err.Error(), "git checkout missing failed") {
+		t.Errorf("Execute error = %v", err)
+	}
+}
+
+func TestRemotesAndPush(t *testing.T) {
+	ctx := context.Background()
+	git, dir := newTestRepo(t)
+
+	if exists, err := git.RemoteExists(ctx, "origin"); err != nil || exists {
+		t.Fatalf("RemoteExists(origin) before add = %v, %v", exists, err)
+	}
+
+	remote := t.TempDir()
+	gitInDir(t, remote, "init", "--bare")
+	gitInDir(t, dir, "remote", "add", "origin", remote)
+
+	remotes, err := git.Remotes(ctx)
+	if err != nil || len(remotes) != 1 || remotes[0] != "origin" {
+		t.Fatalf("Remotes() = %v, %v", remotes, err)
+	}
+	if exists, _ := git.RemoteExists(ctx, "origin"); !exists {
+		t.Error("origin should exist")
+	}
+
+	gitInDir(t, dir, "branch", "feature/x")
+	if err := git.Push(ctx, "origin", "feature/x", true); err != nil {
+		t.Fatalf("Push failed: %v", err)
+	}
+	if got := gitInDir(t, remote, "rev-parse", "feature/x"); got != gitInDir(t, dir, "rev-parse", "feature/x") {
+		t.Errorf("remote feature/x = %s", got)
+	}
+	if upstream, err := git.Upstream(ctx, "feature/x"); err != nil || upstream != "origin/feature/x" {
+		t.Errorf("Upstream(feature/x) = %q, %v", upstream, err)
+	}
+	if upstream, _ := git.Upstream(ctx, "master"); upstream != "" {
+		t.Errorf("Upstream(master) = %q, want none", upstream)
+	}
+	infos, err := git.ListRemoteBranchInfo(ctx, "origin", "feature/")
+	if err != nil || len(infos) != 1 || infos[0].Name != "feature/x" || infos[0].Author == "" {
+		t.Errorf("ListRemoteBranchInfo() = %+v, %v", infos, err)
+	}
+
+	if err := git.CreateTag(ctx, "v1.0.0", "Release 1.0.0"); err != nil {
+		t.Fatal(err)
+	}
+	if err := git.PushTag(ctx, "origin", "v1.0.0"); err != nil {
+		t.Fatalf("PushTag failed: %v", err)
+	}
+	if got := gitInDir(t, remote, "tag", "-l", "v1.0.0"); got != "v1.0.0" {
+		t.Errorf("remote tags = %q", got)
+	}
+
+	if err := git.DeleteRemoteBranch(ctx, "origin", "feature/x"); err != nil {
+		t.Fatalf("DeleteRemoteBranch failed: %v", err)
+	}
+	if got := gitInDir(t, remote, "branch", "--list", "feature/x"); got != "" {
+		t.Errorf("remote feature/x should be deleted, got %q", got)
+	}
+}
+
+func TestForceDeleteBranch(t *testing.T) {
+	ctx := context.Background()
+	git, dir := newTestRepo(t)
+
+	gitInDir(t, dir, "checkout", "-b", "feature/x")
+	gitInDir(t, dir, "commit", "--allow-empty", "-m", "Unmerged work")
+	gitInDir(t, dir, "checkout", "master")
+
+	if err := git.Apply(ctx, Step{Op: OpDeleteBranch, Branch: "feature/x"}); err == nil {
+		t.Fatal("branch -d should refuse an unmerged branch")
+	}
+	step := Step{Op: OpDeleteBranch, Branch: "feature/x", Force: true}
+	if step.String() != "git branch -D feature/x" {
+		t.Errorf("String() = %q", step.String())
+	}
+	if err := git.Apply(ctx, step); err != nil {
+		t.Fatalf("forced delete failed: %v", err)
+	}
+	if exists, _ := git.BranchExists(ctx, "feature/x"); exists {
+		t.Error("feature/x should be deleted")
+	}
+}
+
+func TestFetchTrackAndRebase(t *testing.T) {
+	ctx := context.Background()
+	_, upstreamDir := newTestRepo(t)
+	gitInDir(t, upstreamDir, "checkout", "-b", "feature/x")
+	gitInDir(t, upstreamDir, "commit", "--allow-empty", "-m", "Feature work")
+	gitInDir(t, upstreamDir, "checkout", "master")
+
+	git, dir := newTestRepo(t)
+	gitInDir(t, dir, "remote", "add", "origin", upstreamDir)
+
+	if exists, _ := git.RemoteBranchExists(ctx, "origin", "feature/x"); exists {
+		t.Fatal("origin/feature/x should not exist before fetch")
+	}
+	if err := git.Fetch(ctx, "origin", "feature/x"); err != nil {
+		t.Fatalf("Fetch failed: %v", err)
+	}
+	if exists, err := git.RemoteBranchExists(ctx, "origin", "feature/x"); err != nil || !exists {
+		t.Fatalf("RemoteBranchExists after fetch = %v, %v", exists, err)
+	}
+
+	// The local repository has an unrelated history, so start from the fetched branch
+	if err := git.TrackBranch(ctx, "origin", "feature/x"); err != nil {
+		t.Fatalf("TrackBranch failed: %v", err)
+	}
+	if upstream, _ := git.Upstream(ctx, "feature/x"); upstream != "origin/feature/x" {
+		t.Errorf("Upstream = %q", upstream)
+	}
+
+	// Diverge and rebase local work onto the remote branch
+	gitInDir(t, upstreamDir, "checkout", "feature/x")
+	gitInDir(t, upstreamDir, "commit", "--allow-empty", "-m", "Remote work")
+	gitInDir(t, dir, "commit", "--allow-empty", "-m", "Local work")
+	if err := git.Fetch(ctx, "origin", "feature/x"); err != nil {
+		t.Fatalf("Fetch failed: %v", err)
+	}
+	if rebasing, _ := git.IsRebasing(ctx); rebasing {
+		t.Fatal("no rebase should be in progress")
+	}
+	if err := git.Rebase(ctx, "origin/feature/x"); err != nil {
+		t.Fatalf("Rebase failed: %v", err)
+	}
+	ahead, behind, _ := git.AheadBehind(ctx, "feature/x", "origin/feature/x")
+	if ahead != 1 || behind != 0 {
+		t.Errorf("after rebase: %d ahead, %d behind", ahead, behind)
+	}
+}
+
+func TestRebaseConflictAndAbort(t *testing.T) {
+	ctx := context.Background()
+	git, dir := newTestRepo(t)
+
+	gitInDir(t, dir, "checkout", "-b", "topic")
+	if err := os.WriteFile(filepath.Join(dir, "README.md"), []byte("topic"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	gitInDir(t, dir, "commit", "-am", "Topic change")
+	gitInDir(t, dir, "checkout", "master")
+	if err := os.WriteFile(filepath.Join(dir, "README.md"), []byte("master"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	gitInDir(t, dir, "commit", "-am", "Master change")
+	gitInDir(t, dir, "checkout", "topic")
+
+	if err := git.Rebase(ctx, "master"); err == nil {
+		t.Fatal("Rebase should fail with a conflict")
+	}
+	if rebasing, err := git.IsRebasing(ctx); err != nil || !rebasing {
+		t.Fatalf("IsRebasing = %v, %v", rebasing, err)
+	}
+	if files, _ := git.UnmergedFiles(ctx); len(files) != 1 || files[0] != "README.md" {
+		t.Errorf("UnmergedFiles = %v", files)
+	}
+	if err := git.RebaseAbort(ctx); err != nil {
+		t.Fatalf("RebaseAbort failed: %v", err)
+	}
+	if rebasing, _ := git.IsRebasing(ctx); rebasing {
+		t.Error("rebase should be aborted")
+	}
+}
+
+func TestMergeConflicts(t *testing.T) {
+	ctx := context.Background()
+	git, dir := newTestRepo(t)
+
+	gitInDir(t, dir, "checkout", "-b", "topic")
+	if err := os.WriteFile(filepath.Join(dir, "README.md"), []byte("topic"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(filepath.Join(dir, "new.txt"), []byte("new"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	gitInDir(t, dir, "add", ".")
+	gitInDir(t, dir, "commit", "-m", "Topic change")
+	gitInDir(t, dir, "checkout", "master")
+	gitInDir(t, dir, "branch", "clean")
+	if err := os.WriteFile(filepath.Join(dir, "README.md"), []byte("master"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	gitInDir(t, dir, "commit", "-am", "Master change")
+	head := gitInDir(t, dir, "rev-parse", "HEAD")
+
+	files, err := git.MergeConflicts(ctx, "topic", "master")
+	if err != nil {
+		t.Fatalf("MergeConflicts failed: %v", err)
+	}
+	if len(files) != 1 || files[0] != "README.md" {
+		t.Errorf("MergeConflicts(topic, master) = %v", files)
+	}
+
+	files, err = git.MergeConflicts(ctx, "topic", "clean")
+	if err != nil || len(files) != 0 {
+		t.Errorf("MergeConflicts(topic, clean) = %v, %v", files, err)
+	}
+
+	// Nothing was touched
+	if got := gitInDir(t, dir, "rev-parse", "HEAD"); got != head {
+		t.Errorf("HEAD moved to %s", got)
+	}
+	if clean, _ := git.IsClean(ctx); !clean {
+		t.Error("working tree should stay clean")
+	}
+
+	if _, err := git.MergeConflicts(ctx, "topic", "missing"); err == nil {
+		t.Error("MergeConflicts should fail for a missing branch")
+	}
+}
+
+func TestDiffStatAndChangedFiles(t *testing.T) {
+	ctx := context.Background()
+	git, dir := newTestRepo(t)
+
+	gitInDir(t, dir, "checkout", "-b", "topic")
+	if err := os.MkdirAll(filepath.Join(dir, "src"), 0o755); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(filepath.Join(dir, "src", "a.go"), []byte("one\ntwo\n"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(filepath.Join(dir, "logo.png"), []byte{0, 1, 2, 0}, 0o644); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(filepath.Join(dir, "README.md"), []byte("changed\n"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	gitInDir(t, dir, "add", ".")
+	gitInDir(t, dir, "commit", "-m", "Topic change")
+
+	// Changes on master after the fork are not the topic's
+	gitInDir(t, dir, "checkout", "master")
+	if err := os.WriteFile(filepath.Join(dir, "master.txt"), []byte("x\n"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	gitInDir(t, dir, "add", ".")
+	gitInDir(t, dir, "commit", "-m", "Master change")
+
+	stats, err := git.DiffStat(ctx, "master", "topic")
+	if err != nil {
+		t.Fatalf("DiffStat failed: %v", err)
+	}
+	want := []FileStat{
+		{Path: "README.md", Added: 1, Deleted: 1},
+		{Path: "logo.png", Binary: true},
+		{Path: "src/a.go", Added: 2},
+	}
+	if len(stats) != len(want) {
+		t.Fatalf("DiffStat = %+v, want %+v", stats, want)
+	}
+	for i := range want {
+		if stats[i] != want[i] {
+			t.Errorf("DiffStat[%d] = %+v, want %+v", i, stats[i], want[i])
+		}
+	}
+
+	files, err := git.ChangedFiles(ctx, "master", "topic")
+	if err != nil || strings.Join(files, ",") != "README.md,logo.png,src/a.go" {
+		t.Errorf("ChangedFiles = %v, %v", files, err)
+	}
+
+	stats, err = git.DiffStat(ctx, "master", "topic", "src")
+	if err != nil || len(stats) != 1 || stats[0].Path != "src/a.go" {
+		t.Errorf("DiffStat(src) = %+v, %v", stats, err)
+	}
+	stats, err = git.DiffStat(ctx, "master", "topic", "master.txt")
+	if err != nil || len(stats) != 0 {
+		t.Errorf("DiffStat(master.txt) = %+v, %v", stats, err)
+	}
+
+	if _, err := git.DiffStat(ctx, "master", "-topic"); err == nil {
+		t.Error("DiffStat should reject an invalid branch name")
+	}
+}
+
+func TestRepositoryStateQueries(t *testing.T) {
+	ctx := context.Background()
+	git, dir := newTestRepo(t)
+
+	if op, err := git.InProgress(ctx); err != nil || op != "" {
+		t.Errorf("InProgress() = %q, %v", op, err)
+	}
+	if email, err := git.ConfigValue(ctx, "user.email"); err != nil || email != "test@test.com" {
+		t.Errorf("ConfigValue(user.email) = %q, %v", email, err)
+	}
+	if value, err := git.ConfigValue(ctx, "gzflow.missing"); err != nil || value != "" {
+		t.Errorf("ConfigValue(missing) = %q, %v", value, err)
+	}
+	if shallow, err := git.IsShallow(ctx); err != nil || shallow {
+		t.Errorf("IsShallow() = %v, %v", shallow, err)
+	}
+
+	// Modified and untracked files are reported separately
+	if err := os.WriteFile(filepath.Join(dir, "README.md"), []byte("changed"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(filepath.Join(dir, "new.txt"), []byte("new"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	if files, _ := git.ModifiedFiles(ctx); len(files) != 1 || files[0] != "README.md" {
+		t.Errorf("ModifiedFiles() = %v", files)
+	}
+	if files, _ := git.UntrackedFiles(ctx); len(files) != 1 || files[0] != "new.txt" {
+		t.Errorf("UntrackedFiles() = %v", files)
+	}
+	gitInDir(t, dir, "checkout", "--", "README.md")
+	if err := os.Remove(filepath.Join(dir, "new.txt")); err != nil {
+		t.Fatal(err)
+	}
+
+	// A conflicting cherry-pick stays in progress
+	gitInDir(t, dir, "checkout", "-b", "topic")
+	if err := os.WriteFile(filepath.Join(dir, "README.md"), []byte("topic"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	gitInDir(t, dir, "commit", "-am", "Topic change")
+	gitInDir(t, dir, "checkout", "master")
+	if err := os.WriteFile(filepath.Join(dir, "README.md"), []byte("master"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	gitInDir(t, dir, "commit", "-am", "Master change")
+	cmd := exec.Command("git", "cherry-pick", "topic")
+	cmd.Dir = dir
+	if err := cmd.Run(); err == nil {
+		t.Fatal("cherry-pick should conflict")
+	}
+	if op, _ := git.InProgress(ctx); op != "cherry-pick" {
+		t.Errorf("InProgress() = %q, want cherry-pick", op)
+	}
+	gitInDir(t, dir, "cherry-pick", "--abort")
+
+	if err := git.Merge(ctx, "topic", false); err == nil {
+		t.Fatal("merge should conflict")
+	}
+	if op, _ := git.InProgress(ctx); op != "merge" {
+		t.Errorf("InProgress() = %q, want merge", op)
+	}
+}
+
+func TestDirtySubmodulesAndShallow(t *testing.T) {
+	ctx := context.Background()
+	_, subDir := newTestRepo(t)
+	gitInDir(t, subDir, "commit", "--allow-empty", "-m", "Second commit")
+	git, dir := newTestRepo(t)
+
+	gitInDir(t, dir, "-c", "protocol.file.allow=always", "submodule", "add", "-q", subDir, "lib")
+	gitInDir(t, dir, "commit", "-m", "Add submodule")
+	if dirty, err := git.DirtySubmodules(ctx); err != nil || len(dirty) != 0 {
+		t.Fatalf("DirtySubmodules() = %v, %v", dirty, err)
+	}
+
+	if err := os.WriteFile(filepath.Join(dir, "lib", "README.md"), []byte("changed"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	if dirty, _ := git.DirtySubmodules(ctx); len(dirty) != 1 || dirty[0] != "lib" {
+		t.Errorf("DirtySubmodules() with changes = %v", dirty)
+	}
+	gitInDir(t, filepath.Join(dir, "lib"), "checkout", "--", "README.md")
+
+	gitInDir(t, filepath.Join(dir, "lib"), "checkout", "-q", "HEAD~1")
+	if dirty, _ := git.DirtySubmodules(ctx); len(dirty) != 1 {
+		t.Errorf("DirtySubmodules() with moved commit = %v", dirty)
+	}
+
+	shallowDir := t.TempDir()
+	gitInDir(t, shallowDir, "clone", "-q", "--depth", "1", "file://"+subDir, ".")
+	if shallow, err := New().WithWorkDir(shallowDir).IsShallow(ctx); err != nil || !shallow {
+		t.Errorf("IsShallow() = %v, %v", shallow, err)
+	}
+}
+
+func TestStash(t *testing.T) {
+	ctx := context.Background()
+	git, dir := newTestRepo(t)
+
+	if err := os.WriteFile(filepath.Join(dir, "README.md"), []byte("edited"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(filepath.Join(dir, "notes.txt"), []byte("new"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	step := Step{Op: OpStash, Message: "gz-flow autostash: master"}
+	if step.String() != `git stash push --include-untracked -m "gz-flow autostash: master"` {
+		t.Errorf("String() = %q", step.String())
+	}
+	if err := git.Apply(ctx, step); err != nil {
+		t.Fatalf("stash failed: %v", err)
+	}
+	if clean, _ := git.IsClean(ctx); !clean {
+		t.Fatal("stash should clean the working tree, untracked files included")
+	}
+
+	entries, err := git.StashList(ctx)
+	if err != nil {
+		t.Fatalf("StashList failed: %v", err)
+	}
+	want := StashEntry{Ref: "stash@{0}", Branch: "master", Message: "gz-flow autostash: master"}
+	if len(entries) != 1 || entries[0] != want {
+		t.Fatalf("StashList = %+v, want %+v", entries, want)
+	}
+
+	if err := git.StashPop(ctx, "--all"); err == nil {
+		t.Error("StashPop should reject an invalid entry")
+	}
+	if err := git.Apply(ctx, Step{Op: OpStashPop, Stash: "stash@{0}"}); err != nil {
+		t.Fatalf("stash pop failed: %v", err)
+	}
+	if data, _ := os.ReadFile(filepath.Join(dir, "notes.txt")); string(data) != "new" {
+		t.Error("untracked file not restored")
+	}
+	if entries, _ := git.StashList(ctx); len(entries) != 0 {
+		t.Errorf("stash should be empty: %+v", entries)
+	}
+}
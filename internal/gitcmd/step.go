@@ -0,0 +1,160 @@
+package gitcmd
+
+import (
+	"context"
+	"fmt"
+	"strings"
+)
+
+// Op is the kind of git operation performed by a Step
+type Op string
+
+const (
+	OpCheckout           Op = "checkout"
+	OpCreateBranch       Op = "create-branch"
+	OpMerge              Op = "merge"
+	OpTag                Op = "tag"
+	OpDeleteBranch       Op = "delete-branch"
+	OpPush               Op = "push"
+	OpFetch              Op = "fetch"
+	OpTrack              Op = "track"
+	OpRebase             Op = "rebase"
+	OpPushTag            Op = "push-tag"
+	OpDeleteRemoteBranch Op = "delete-remote-branch"
+	OpStash              Op = "stash"
+	OpStashPop           Op = "stash-pop"
+)
+
+// Step is a single git operation of a multi-step flow.
+// Steps are plain data so an interrupted flow can be persisted and resumed.
+type Step struct {
+	Op       Op     `json:"op"`
+	Branch   string `json:"branch,omitempty"` // branch checked out, created, merged, deleted, pushed or fetched; rebase target
+	Into     string `json:"into,omitempty"`   // merge target; must be checked out by a previous step
+	Tag      string `json:"tag,omitempty"`    // tag created or pushed
+	Message  string `json:"message,omitempty"`
+	NoFF     bool   `json:"no_ff,omitempty"`
+	FFOnly   bool   `json:"ff_only,omitempty"`  // merge only by fast-forward, keeping the history linear
+	Remote   string `json:"remote,omitempty"`   // remote of push, fetch, track and remote delete steps
+	Upstream bool   `json:"upstream,omitempty"` // push sets the upstream of Branch
+	Optional bool   `json:"optional,omitempty"` // failure is reported but does not stop the flow
+	Force    bool   `json:"force,omitempty"`    // delete a branch even if HEAD does not contain it
+	Stash    string `json:"stash,omitempty"`    // stash entry restored, e.g. stash@{0}
+}
+
+// String renders the step as the git command it runs
+func (s Step) String() string {
+	switch s.Op {
+	case OpCheckout:
+		return "git checkout " + s.Branch
+	case OpCreateBranch:
+		return "git checkout -b " + s.Branch
+	case OpMerge:
+		args := []string{"git", "merge"}
+		if s.NoFF {
+			args = append(args, "--no-ff")
+		}
+		if s.FFOnly {
+			args = append(args, "--ff-only")
+		}
+		return strings.Join(append(args, s.Branch), " ")
+	case OpTag:
+		return fmt.Sprintf("git tag -a %s -m %q", s.Tag, s.Message)
+	case OpDeleteBranch:
+		if s.Force {
+			return "git branch -D " + s.Branch
+		}
+		return "git branch -d " + s.Branch
+	case OpPush:
+		if s.Upstream {
+			return fmt.Sprintf("git push --set-upstream %s %s", s.Remote, s.Branch)
+		}
+		return fmt.Sprintf("git push %s %s", s.Remote, s.Branch)
+	case OpFetch:
+		return fmt.Sprintf("git fetch %s %s", s.Remote, s.Branch)
+	case OpTrack:
+		return fmt.Sprintf("git checkout --track -b %s %s/%s", s.Branch, s.Remote, s.Branch)
+	case OpRebase:
+		return "git rebase " + s.Branch
+	case OpPushTag:
+		return fmt.Sprintf("git push %s refs/tags/%s", s.Remote, s.Tag)
+	case OpDeleteRemoteBranch:
+		return fmt.Sprintf("git push %s --delete %s", s.Remote, s.Branch)
+	case OpStash:
+		return fmt.Sprintf("git stash push --include-untracked -m %q", s.Message)
+	case OpStashPop:
+		return "git stash pop " + s.Stash
+	}
+	return fmt.Sprintf("<unknown step %q>", s.Op)
+}
+
+// Apply executes a single step
+func (e *Executor) Apply(ctx context.Context, s Step) error {
+	switch s.Op {
+	case OpCheckout:
+		return e.Checkout(ctx, s.Branch)
+	case OpCreateBranch:
+		return e.CreateBranch(ctx, s.Branch)
+	case OpMerge:
+		if s.FFOnly {
+			return e.MergeFastForward(ctx, s.Branch)
+		}
+		return e.Merge(ctx, s.Branch, s.NoFF)
+	case OpTag:
+		return e.CreateTag(ctx, s.Tag, s.Message)
+	case OpDeleteBranch:
+		if s.Force {
+			return e.ForceDeleteBranch(ctx, s.Branch)
+		}
+		return e.DeleteBranch(ctx, s.Branch)
+	case OpPush:
+		return e.Push(ctx, s.Remote, s.Branch, s.Upstream)
+	case OpFetch:
+		return e.Fetch(ctx, s.Remote, s.Branch)
+	case OpTrack:
+		return e.TrackBranch(ctx, s.Remote, s.Branch)
+	case OpRebase:
+		return e.Rebase(ctx, s.Branch)
+	case OpPushTag:
+		return e.PushTag(ctx, s.Remote, s.Tag)
+	case OpDeleteRemoteBranch:
+		return e.DeleteRemoteBranch(ctx, s.Remote, s.Branch)
+	case OpStash:
+		return e.StashPush(ctx, s.Message)
+	case OpStashPop:
+		return e.StashPop(ctx, s.Stash)
+	}
+	return fmt.Errorf("unknown step %q", s.Op)
+}
+
+// Plan is the ordered list of steps a command performs.
+// Commands build a plan first so it can be shown with --dry-run or executed.
+type Plan []Step
+
+// Commands returns the git command of each step
+func (p Plan) Commands() []string {
+	cmds := make([]string, len(p))
+	for i, s := range p {
+		cmds[i] = s.String()
+	}
+	return cmds
+}
+
+// String renders the plan as numbered git commands, one per line
+func (p Plan) String() string {
+	var b strings.Builder
+	for i, s := range p {
+		fmt.Fprintf(&b, "  %d. %s\n", i+1, s)
+	}
+	return b.String()
+}
+
+// Execute applies every step of the plan in order, stopping at the first failure
+func (e *Executor) Execute(ctx context.Context, p Plan) error {
+	for _, s := range p {
+		if err := e.Apply(ctx, s); err != nil {
+			return fmt.Errorf("%s failed: %w", s, err)
+		}
+	}
+	return nil
+}
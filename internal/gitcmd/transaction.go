@@ -0,0 +1,120 @@
+package gitcmd
+
+import (
+	"context"
+	"fmt"
+	"sort"
+)
+
+// Transaction records the refs a multi-step operation touches so the
+// repository can be restored exactly to its prior state if the operation fails.
+// It is plain data so it can be persisted alongside the remaining steps.
+type Transaction struct {
+	OriginalBranch string            `json:"original_branch"`
+	OriginalRefs   map[string]string `json:"original_refs"` // branch -> SHA
+	CreatedTags    []string          `json:"created_tags,omitempty"`
+}
+
+// Begin records the current branch and the SHA of every given branch.
+// Empty and duplicate names are ignored.
+func (e *Executor) Begin(ctx context.Context, branches ...string) (*Transaction, error) {
+	current, _ := e.CurrentBranch(ctx)
+	tx := &Transaction{
+		OriginalBranch: current,
+		OriginalRefs:   map[string]string{},
+	}
+
+	for _, branch := range branches {
+		if _, seen := tx.OriginalRefs[branch]; branch == "" || seen {
+			continue
+		}
+		sha, err := e.RevParse(ctx, branch)
+		if err != nil {
+			return nil, fmt.Errorf("failed to resolve '%s': %w", branch, err)
+		}
+		tx.OriginalRefs[branch] = sha
+	}
+	return tx, nil
+}
+
+// Track records the side effects of a step that was applied successfully
+func (t *Transaction) Track(s Step) {
+	if s.Op == OpTag {
+		t.CreatedTags = append(t.CreatedTags, s.Tag)
+	}
+}
+
+// rollbackStashMessage names the stash entry that keeps uncommitted changes
+// out of the way of a rollback
+const rollbackStashMessage = "gz-flow rollback: uncommitted changes"
+
+// Rollback aborts any merge in progress, resets every recorded branch to its
+// original SHA, deletes the tags created during the transaction and checks out
+// the original branch. It returns the branches that had to be reset.
+// Uncommitted changes are stashed before the checked-out branch is reset and
+// restored on the original branch, so the reset does not discard them.
+func (e *Executor) Rollback(ctx context.Context, t *Transaction) ([]string, error) {
+	if merging, _ := e.IsMerging(ctx); merging {
+		if err := e.MergeAbort(ctx); err != nil {
+			return nil, fmt.Errorf("failed to abort merge: %w", err)
+		}
+	}
+
+	current, _ := e.CurrentBranch(ctx)
+	branches := make([]string, 0, len(t.OriginalRefs))
+	for branch := range t.OriginalRefs {
+		branches = append(branches, branch)
+	}
+	sort.Strings(branches)
+
+	var restored []string
+	stashed := false
+	for _, branch := range branches {
+		sha := t.OriginalRefs[branch]
+		if now, err := e.RevParse(ctx, branch); err == nil && now == sha {
+			continue
+		}
+
+		var err error
+		if branch == current {
+			clean, cleanErr := e.IsClean(ctx)
+			if cleanErr != nil {
+				return restored, fmt.Errorf("failed to check the working tree: %w", cleanErr)
+			}
+			if !clean {
+				if err := e.StashPush(ctx, rollbackStashMessage); err != nil {
+					return restored, fmt.Errorf("failed to stash uncommitted changes: %w", err)
+				}
+				stashed = true
+			}
+			err = e.ResetHard(ctx, sha)
+		} else {
+			err = e.SetBranch(ctx, branch, sha)
+		}
+		if err != nil {
+			return restored, fmt.Errorf("failed to restore '%s': %w", branch, err)
+		}
+		restored = append(restored, branch)
+	}
+
+	for _, tag := range t.CreatedTags {
+		if exists, _ := e.TagExists(ctx, tag); !exists {
+			continue
+		}
+		if err := e.DeleteTag(ctx, tag); err != nil {
+			return restored, fmt.Errorf("failed to delete tag '%s': %w", tag, err)
+		}
+	}
+
+	if t.OriginalBranch != "" && t.OriginalBranch != current {
+		if err := e.Checkout(ctx, t.OriginalBranch); err != nil {
+			return restored, fmt.Errorf("failed to checkout %s: %w", t.OriginalBranch, err)
+		}
+	}
+	if stashed {
+		if err := e.StashPop(ctx, "stash@{0}"); err != nil {
+			return restored, fmt.Errorf("failed to restore uncommitted changes (kept as %q in git stash list): %w", rollbackStashMessage, err)
+		}
+	}
+	return restored, nil
+}
@@ -12,40 +12,70 @@ type GitExecutor interface {
 	IsClean(ctx context.Context) (bool, error)
 	BranchExists(ctx context.Context, branch string) (bool, error)
 	CurrentBranch(ctx context.Context) (string, error)
+	InProgress(ctx context.Context) (string, error)
+	ModifiedFiles(ctx context.Context) ([]string, error)
+	UntrackedFiles(ctx context.Context) ([]string, error)
+	UnmergedFiles(ctx context.Context) ([]string, error)
+	DirtySubmodules(ctx context.Context) ([]string, error)
+	IsShallow(ctx context.Context) (bool, error)
+	ConfigValue(ctx context.Context, key string) (string, error)
+	Fetch(ctx context.Context, remote string, branches ...string) error
+	RemoteBranchExists(ctx context.Context, remote, branch string) (bool, error)
+	AheadBehind(ctx context.Context, branch, base string) (int, int, error)
+	MergeConflicts(ctx context.Context, branch, into string) ([]string, error)
 }
 
 // Result represents the result of a single pre-flight check
 type Result struct {
-	Name   string
-	Passed bool
-	Error  string
-	Hint   string
+	Name    string `json:"name" yaml:"name"`
+	Check   string `json:"check,omitempty" yaml:"check,omitempty"` // name of the check that produced it
+	Passed  bool   `json:"passed" yaml:"passed"`
+	Warning bool   `json:"warning,omitempty" yaml:"warning,omitempty"` // failed, but does not block
+	Error   string `json:"error,omitempty" yaml:"error,omitempty"`
+	Hint    string `json:"hint,omitempty" yaml:"hint,omitempty"`
 }
 
 // Results is a collection of pre-flight check results
 type Results []Result
 
-// HasErrors returns true if any check failed
+// HasErrors returns true if any check failed, not counting warnings
 func (r Results) HasErrors() bool {
 	for _, result := range r {
-		if !result.Passed {
+		if !result.Passed && !result.Warning {
 			return true
 		}
 	}
 	return false
 }
 
+// Warnings returns the failed results that do not block
+func (r Results) Warnings() Results {
+	var warnings Results
+	for _, result := range r {
+		if !result.Passed && result.Warning {
+			warnings = append(warnings, result)
+		}
+	}
+	return warnings
+}
+
 // String formats the results for display
 func (r Results) String() string {
 	var sb strings.Builder
 	sb.WriteString("Pre-flight checks:\n")
 	for _, result := range r {
-		if result.Passed {
+		switch {
+		case result.Passed:
 			sb.WriteString(fmt.Sprintf("  ✅ %s\n", result.Name))
-		} else {
+		case result.Warning:
+			sb.WriteString(fmt.Sprintf("  ⚠️  %s\n", result.Name))
+		default:
 			sb.WriteString(fmt.Sprintf("  ❌ %s\n", result.Name))
+		}
+		if !result.Passed {
 			if result.Error != "" {
-				sb.WriteString(fmt.Sprintf("     Error: %s\n", result.Error))
+				// Indent continuation lines, e.g. the output of a command check
+				sb.WriteString(fmt.Sprintf("     Error: %s\n", strings.ReplaceAll(result.Error, "\n", "\n            ")))
 			}
 			if result.Hint != "" {
 				sb.WriteString(fmt.Sprintf("     💡 %s\n", result.Hint))
@@ -55,83 +85,445 @@ func (r Results) String() string {
 	return sb.String()
 }
 
+// Names of the built-in checks, used to enable and disable them in config
+const (
+	CheckDetachedHead        = "detached-head"
+	CheckInProgress          = "operation-in-progress"
+	CheckUnresolvedConflicts = "unresolved-conflicts"
+	CheckCleanTree           = "clean-tree"
+	CheckDirtySubmodules     = "dirty-submodules"
+	CheckUntrackedFiles      = "untracked-files"
+	CheckUserEmail           = "user-email"
+	CheckShallowClone        = "shallow-clone"
+	CheckTargetExists        = "target-exists"
+	CheckUpToDate            = "up-to-date"
+	CheckLinearHistory       = "linear-history"
+	CheckMergeConflicts      = "merge-conflicts"
+
+	// CheckFetch labels the fetch of the up-to-date check; it cannot be
+	// selected on its own
+	CheckFetch = "fetch"
+)
+
+// maxListed is how many paths a failed result lists before summarizing
+const maxListed = 5
+
+// Builtin returns the built-in checks in their default run order
+func Builtin() []Check {
+	return []Check{
+		NewCheck(CheckDetachedHead, checkDetachedHead),
+		NewCheck(CheckInProgress, checkInProgress),
+		NewCheck(CheckUnresolvedConflicts, checkUnresolvedConflicts),
+		NewCheck(CheckCleanTree, checkCleanTree),
+		NewCheck(CheckDirtySubmodules, checkDirtySubmodules),
+		NewCheck(CheckUntrackedFiles, checkUntrackedFiles),
+		NewCheck(CheckUserEmail, checkUserEmail),
+		NewCheck(CheckShallowClone, checkShallowClone),
+		NewCheck(CheckTargetExists, checkTargetExists),
+		NewCheck(CheckUpToDate, checkRemoteUpToDate),
+		NewCheck(CheckLinearHistory, checkLinearHistory),
+		NewCheck(CheckMergeConflicts, checkMergeConflicts),
+	}
+}
+
 // Checker performs pre-flight checks before git-flow operations
 type Checker struct {
-	git          GitExecutor
-	targetBranch string
+	git    GitExecutor
+	op     Operation
+	checks []Check
 }
 
-// NewChecker creates a new Checker
+// NewChecker creates a Checker that runs the built-in checks against targetBranch
 func NewChecker(git GitExecutor, targetBranch string) *Checker {
-	return &Checker{
-		git:          git,
-		targetBranch: targetBranch,
+	c := &Checker{
+		git:    git,
+		checks: Builtin(),
 	}
+	if targetBranch != "" {
+		c.op.Targets = []string{targetBranch}
+	}
+	return c
+}
+
+// WithOperation sets the operation the checks validate
+func (c *Checker) WithOperation(op Operation) *Checker {
+	c.op = op
+	return c
+}
+
+// WithChecks replaces the checks to run, in order
+func (c *Checker) WithChecks(checks ...Check) *Checker {
+	c.checks = checks
+	return c
 }
 
 // RunAll runs all pre-flight checks
 func (c *Checker) RunAll(ctx context.Context) Results {
 	var results Results
+	for _, check := range c.checks {
+		for _, result := range check.Run(ctx, c.git, c.op) {
+			if result.Check == "" {
+				result.Check = check.Name()
+			}
+			results = append(results, result)
+		}
+	}
+	return results
+}
 
-	// Check 1: Clean working tree
-	results = append(results, c.checkCleanTree(ctx))
+// checkDetachedHead verifies HEAD is on a branch
+func checkDetachedHead(ctx context.Context, git GitExecutor, op Operation) Results {
+	const name = "HEAD is on a branch"
+	branch, err := git.CurrentBranch(ctx)
+	if err != nil {
+		return Results{{Name: name, Passed: false, Error: err.Error()}}
+	}
+	if branch == "" {
+		return Results{{
+			Name:   name,
+			Passed: false,
+			Error:  "HEAD is detached",
+			Hint:   "Switch to a branch first (git switch <branch>); commits made on a detached HEAD are easy to lose",
+		}}
+	}
+	return Results{{Name: name, Passed: true}}
+}
 
-	// Check 2: Target branch exists (if specified)
-	if c.targetBranch != "" {
-		results = append(results, c.checkBranchUpToDate(ctx))
+// checkInProgress verifies no rebase, merge, cherry-pick or revert is waiting
+// to be continued
+func checkInProgress(ctx context.Context, git GitExecutor, op Operation) Results {
+	const name = "No rebase, merge or cherry-pick in progress"
+	inProgress, err := git.InProgress(ctx)
+	if err != nil {
+		return Results{{Name: name, Passed: false, Error: err.Error()}}
+	}
+	if inProgress != "" {
+		return Results{{
+			Name:   name,
+			Passed: false,
+			Error:  fmt.Sprintf("a %s is in progress", inProgress),
+			Hint:   fmt.Sprintf("Complete it with 'git %[1]s --continue' or cancel it with 'git %[1]s --abort'", inProgress),
+		}}
 	}
+	return Results{{Name: name, Passed: true}}
+}
 
-	return results
+// checkUnresolvedConflicts verifies the index has no conflicted paths
+func checkUnresolvedConflicts(ctx context.Context, git GitExecutor, op Operation) Results {
+	const name = "No unresolved conflicts"
+	files, err := git.UnmergedFiles(ctx)
+	if err != nil {
+		return Results{{Name: name, Passed: false, Error: err.Error()}}
+	}
+	if len(files) > 0 {
+		return Results{{
+			Name:   name,
+			Passed: false,
+			Error:  "conflicts in " + listPaths(files),
+			Hint:   "Resolve the conflicts and 'git add' the files, or discard them with 'git reset --merge'",
+		}}
+	}
+	return Results{{Name: name, Passed: true}}
 }
 
-// checkCleanTree verifies the working directory is clean
-func (c *Checker) checkCleanTree(ctx context.Context) Result {
-	clean, err := c.git.IsClean(ctx)
+// checkCleanTree verifies no tracked file has uncommitted changes. Untracked
+// files are left to checkUntrackedFiles.
+func checkCleanTree(ctx context.Context, git GitExecutor, op Operation) Results {
+	clean, err := git.IsClean(ctx)
+	if err == nil && !clean {
+		var modified []string
+		if modified, err = git.ModifiedFiles(ctx); err == nil {
+			clean = len(modified) == 0
+		}
+	}
 	if err != nil {
-		return Result{
+		return Results{{
 			Name:   "Clean working tree",
 			Passed: false,
 			Error:  err.Error(),
 			Hint:   "Failed to check git status",
-		}
+		}}
 	}
 
 	if !clean {
-		return Result{
+		return Results{{
 			Name:   "Clean working tree",
 			Passed: false,
 			Hint:   "Commit or stash your changes before finishing",
-		}
+		}}
 	}
 
-	return Result{
+	return Results{{
 		Name:   "Clean working tree",
 		Passed: true,
+	}}
+}
+
+// checkDirtySubmodules verifies every submodule is at its recorded commit
+// without local changes
+func checkDirtySubmodules(ctx context.Context, git GitExecutor, op Operation) Results {
+	const name = "Submodules are clean"
+	dirty, err := git.DirtySubmodules(ctx)
+	if err != nil {
+		return Results{{Name: name, Passed: false, Error: err.Error()}}
+	}
+	if len(dirty) > 0 {
+		return Results{{
+			Name:   name,
+			Passed: false,
+			Error:  "modified submodules: " + listPaths(dirty),
+			Hint:   "Commit the submodule changes, or reset them with 'git submodule update --recursive'",
+		}}
+	}
+	return Results{{Name: name, Passed: true}}
+}
+
+// checkUntrackedFiles verifies there are no untracked files. It only applies
+// in strict mode.
+func checkUntrackedFiles(ctx context.Context, git GitExecutor, op Operation) Results {
+	if !op.Strict {
+		return nil
+	}
+	const name = "No untracked files"
+	files, err := git.UntrackedFiles(ctx)
+	if err != nil {
+		return Results{{Name: name, Passed: false, Error: err.Error()}}
+	}
+	if len(files) > 0 {
+		return Results{{
+			Name:   name,
+			Passed: false,
+			Error:  "untracked: " + listPaths(files),
+			Hint:   "Commit, remove or .gitignore them (guardian.mode: permissive allows untracked files)",
+		}}
+	}
+	return Results{{Name: name, Passed: true}}
+}
+
+// checkUserEmail verifies git can author the merge commits
+func checkUserEmail(ctx context.Context, git GitExecutor, op Operation) Results {
+	const name = "git user.email is set"
+	email, err := git.ConfigValue(ctx, "user.email")
+	if err != nil {
+		return Results{{Name: name, Passed: false, Error: err.Error()}}
+	}
+	if email == "" {
+		return Results{{
+			Name:   name,
+			Passed: false,
+			Hint:   "Merge commits need an author: git config --global user.email you@example.com",
+		}}
 	}
+	return Results{{Name: name, Passed: true}}
 }
 
-// checkBranchUpToDate verifies target branch exists
-func (c *Checker) checkBranchUpToDate(ctx context.Context) Result {
-	exists, err := c.git.BranchExists(ctx, c.targetBranch)
+// checkShallowClone verifies the full history is available, so merge bases
+// are found and merges do not fail or pull in unrelated history
+func checkShallowClone(ctx context.Context, git GitExecutor, op Operation) Results {
+	const name = "Repository is not a shallow clone"
+	shallow, err := git.IsShallow(ctx)
 	if err != nil {
-		return Result{
-			Name:   fmt.Sprintf("Target branch '%s' exists", c.targetBranch),
+		return Results{{Name: name, Passed: false, Error: err.Error()}}
+	}
+	if shallow {
+		return Results{{
+			Name:   name,
+			Passed: false,
+			Hint:   "Fetch the full history first: git fetch --unshallow",
+		}}
+	}
+	return Results{{Name: name, Passed: true}}
+}
+
+// checkTargetExists verifies the first target branch exists
+func checkTargetExists(ctx context.Context, git GitExecutor, op Operation) Results {
+	if len(op.Targets) == 0 {
+		return nil
+	}
+	target := op.Targets[0]
+
+	exists, err := git.BranchExists(ctx, target)
+	if err != nil {
+		return Results{{
+			Name:   fmt.Sprintf("Target branch '%s' exists", target),
 			Passed: false,
 			Error:  err.Error(),
 			Hint:   "Failed to check if branch exists",
-		}
+		}}
 	}
 
 	if !exists {
-		return Result{
-			Name:   fmt.Sprintf("Target branch '%s' exists", c.targetBranch),
+		return Results{{
+			Name:   fmt.Sprintf("Target branch '%s' exists", target),
 			Passed: false,
-			Hint:   fmt.Sprintf("Create branch '%s' first or check your configuration", c.targetBranch),
-		}
+			Hint:   fmt.Sprintf("Create branch '%s' first or check your configuration", target),
+		}}
 	}
 
-	return Result{
-		Name:   fmt.Sprintf("Target branch '%s' exists", c.targetBranch),
+	return Results{{
+		Name:   fmt.Sprintf("Target branch '%s' exists", target),
 		Passed: true,
+	}}
+}
+
+// checkRemoteUpToDate verifies that no target nor the source branch is behind
+// its remote-tracking branch. Branches that do not exist locally or on the
+// remote are skipped, as are operations without a remote. A failed fetch only
+// warns; the branches are then compared with the last fetch.
+func checkRemoteUpToDate(ctx context.Context, git GitExecutor, op Operation) Results {
+	if op.Remote == "" {
+		return nil
+	}
+
+	var results Results
+	if op.Fetch {
+		if err := git.Fetch(ctx, op.Remote); err != nil {
+			// Reported on its own: being offline is no rule violation
+			results = append(results, Result{
+				Name:    fmt.Sprintf("Fetch '%s'", op.Remote),
+				Check:   CheckFetch,
+				Passed:  false,
+				Warning: true,
+				Error:   err.Error(),
+				Hint:    "Comparing with the last fetch; pass --no-fetch to skip fetching",
+			})
+		}
+	}
+
+	branches := append([]string{}, op.Targets...)
+	if op.Source != "" {
+		branches = append(branches, op.Source)
+	}
+	for _, branch := range branches {
+		remoteBranch := op.Remote + "/" + branch
+		name := fmt.Sprintf("'%s' is up to date with '%s'", branch, remoteBranch)
+
+		if exists, err := git.BranchExists(ctx, branch); err != nil || !exists {
+			continue
+		}
+		published, err := git.RemoteBranchExists(ctx, op.Remote, branch)
+		if err != nil {
+			results = append(results, Result{Name: name, Passed: false, Error: err.Error()})
+			continue
+		}
+		if !published {
+			continue
+		}
+
+		_, behind, err := git.AheadBehind(ctx, branch, remoteBranch)
+		if err != nil {
+			results = append(results, Result{Name: name, Passed: false, Error: err.Error()})
+			continue
+		}
+		if behind > 0 {
+			results = append(results, Result{
+				Name:   name,
+				Passed: false,
+				Error:  fmt.Sprintf("%s is %d %s behind %s", branch, behind, commits(behind), remoteBranch),
+				Hint:   fmt.Sprintf("Update it first: git checkout %s && git pull %s %s", branch, op.Remote, branch),
+			})
+			continue
+		}
+		results = append(results, Result{Name: name, Passed: true})
+	}
+	return results
+}
+
+// checkLinearHistory verifies the source branch contains every commit of its
+// targets, so merging it does not fork the history. Targets that do not exist
+// are skipped.
+func checkLinearHistory(ctx context.Context, git GitExecutor, op Operation) Results {
+	if op.Source == "" {
+		return nil
+	}
+
+	var results Results
+	for _, target := range op.Targets {
+		name := fmt.Sprintf("'%s' is rebased on '%s'", op.Source, target)
+		if exists, err := git.BranchExists(ctx, target); err != nil || !exists {
+			continue
+		}
+
+		_, behind, err := git.AheadBehind(ctx, op.Source, target)
+		if err != nil {
+			results = append(results, Result{Name: name, Passed: false, Error: err.Error()})
+			continue
+		}
+		if behind > 0 {
+			results = append(results, Result{
+				Name:   name,
+				Passed: false,
+				Error:  fmt.Sprintf("%s is missing %d %s of %s", op.Source, behind, commits(behind), target),
+				Hint:   fmt.Sprintf("Linear history is required; rebase first: git rebase %s %s", target, op.Source),
+			})
+			continue
+		}
+		results = append(results, Result{Name: name, Passed: true})
+	}
+	return results
+}
+
+// checkMergeConflicts simulates each merge of the source branch and lists the
+// paths that would conflict. A source or targets that do not exist are skipped.
+func checkMergeConflicts(ctx context.Context, git GitExecutor, op Operation) Results {
+	if op.Source == "" {
+		return nil
+	}
+	if exists, err := git.BranchExists(ctx, op.Source); err != nil || !exists {
+		return nil
+	}
+
+	var results Results
+	for _, target := range op.Targets {
+		name := fmt.Sprintf("'%s' merges cleanly into '%s'", op.Source, target)
+		if exists, err := git.BranchExists(ctx, target); err != nil || !exists {
+			continue
+		}
+
+		files, err := git.MergeConflicts(ctx, op.Source, target)
+		if err != nil {
+			result := Result{Name: name, Passed: false, Error: err.Error()}
+			if noWriteTree(err) {
+				result.Hint = "Merge prediction needs git 2.38 or newer"
+			}
+			results = append(results, result)
+			continue
+		}
+		if len(files) > 0 {
+			results = append(results, Result{
+				Name:   name,
+				Passed: false,
+				Error:  fmt.Sprintf("conflicts in %s", strings.Join(files, ", ")),
+				Hint:   fmt.Sprintf("Merge '%s' into '%s' and resolve the conflicts there first, or pass --allow-conflicts to resolve them during the finish", target, op.Source),
+			})
+			continue
+		}
+		results = append(results, Result{Name: name, Passed: true})
+	}
+	return results
+}
+
+// noWriteTree reports whether err comes from a git merge-tree without
+// --write-tree, added in git 2.38. Older versions reject the option or
+// print the usage of the three-tree form.
+func noWriteTree(err error) bool {
+	msg := err.Error()
+	return strings.Contains(msg, "unknown option `write-tree'") ||
+		strings.Contains(msg, "usage: git merge-tree <base-tree>")
+}
+
+// listPaths joins paths for display, summarizing all but the first few
+func listPaths(paths []string) string {
+	if len(paths) <= maxListed {
+		return strings.Join(paths, ", ")
+	}
+	return fmt.Sprintf("%s and %d more", strings.Join(paths[:maxListed], ", "), len(paths)-maxListed)
+}
+
+// commits returns "commit" or "commits" for n
+func commits(n int) string {
+	if n == 1 {
+		return "commit"
 	}
+	return "commits"
 }
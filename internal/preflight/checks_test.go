@@ -2,6 +2,7 @@ package preflight_test
 
 import (
 	"context"
+	"errors"
 	"strings"
 	"testing"
 
@@ -139,3 +140,257 @@ func TestResult_HasErrors(t *testing.T) {
 		})
 	}
 }
+
+func TestChecker_RemoteUpToDate(t *testing.T) {
+	t.Run("behind remote", func(t *testing.T) {
+		mockGit := &testdata.MockGit{
+			AheadBehindFunc: func(ctx context.Context, branch, base string) (int, int, error) {
+				if branch == "develop" && base == "origin/develop" {
+					return 0, 3, nil
+				}
+				return 2, 0, nil // ahead only is fine
+			},
+		}
+
+		results := preflight.NewChecker(mockGit, "").
+			WithOperation(preflight.Operation{Targets: []string{"master", "develop"}, Remote: "origin", Fetch: true}).
+			RunAll(context.Background())
+
+		if !results.HasErrors() {
+			t.Fatal("Expected an error when develop is behind origin")
+		}
+		output := results.String()
+		if !strings.Contains(output, "develop is 3 commits behind origin/develop") {
+			t.Errorf("Expected behind count in output, got:\n%s", output)
+		}
+		if !strings.Contains(output, "✅ 'master' is up to date with 'origin/master'") {
+			t.Errorf("Expected master to pass, got:\n%s", output)
+		}
+	})
+
+	t.Run("fetch failure", func(t *testing.T) {
+		mockGit := &testdata.MockGit{
+			FetchFunc: func(ctx context.Context, remote string, branches ...string) error {
+				return errors.New("could not resolve host")
+			},
+			RemoteBranchExistsFunc: func(ctx context.Context, remote, branch string) (bool, error) {
+				return true, nil
+			},
+			AheadBehindFunc: func(ctx context.Context, branch, base string) (int, int, error) {
+				return 0, 1, nil
+			},
+		}
+
+		results := preflight.NewChecker(mockGit, "").
+			WithOperation(preflight.Operation{Targets: []string{"develop"}, Remote: "origin", Fetch: true}).
+			RunAll(context.Background())
+
+		// The fetch only warns; the last fetch still shows develop behind
+		warnings := results.Warnings()
+		if len(warnings) != 1 || warnings[0].Check != preflight.CheckFetch || !strings.Contains(warnings[0].Hint, "last fetch") {
+			t.Errorf("Expected a fetch warning, got:\n%s", results)
+		}
+		if !results.HasErrors() || !strings.Contains(results.String(), "develop is 1 commit behind origin/develop") {
+			t.Errorf("Expected the comparison with the last fetch, got:\n%s", results)
+		}
+	})
+
+	t.Run("no fetch and unpublished branches", func(t *testing.T) {
+		mockGit := &testdata.MockGit{
+			FetchFunc: func(ctx context.Context, remote string, branches ...string) error {
+				t.Error("Fetch should not be called")
+				return nil
+			},
+			RemoteBranchExistsFunc: func(ctx context.Context, remote, branch string) (bool, error) {
+				return branch == "develop", nil
+			},
+		}
+
+		results := preflight.NewChecker(mockGit, "").
+			WithOperation(preflight.Operation{Source: "feature/x", Targets: []string{"develop"}, Remote: "origin"}).
+			RunAll(context.Background())
+
+		if results.HasErrors() {
+			t.Errorf("Expected no errors, got:\n%s", results)
+		}
+		if output := results.String(); strings.Contains(output, "'feature/x' is up to date") {
+			t.Errorf("Expected the unpublished branch to be skipped, got:\n%s", output)
+		}
+	})
+}
+
+func TestChecker_MergeConflicts(t *testing.T) {
+	mockGit := &testdata.MockGit{
+		BranchExistsFunc: func(ctx context.Context, branch string) (bool, error) {
+			return branch != "missing", nil
+		},
+		MergeConflictsFunc: func(ctx context.Context, branch, into string) ([]string, error) {
+			switch into {
+			case "develop":
+				return []string{"VERSION", "go.mod"}, nil
+			case "old-git":
+				return nil, errors.New("git merge-tree --write-tree: exit status 129: error: unknown option `write-tree'")
+			case "broken":
+				return nil, errors.New("git merge-tree --write-tree: exit status 128: fatal: bad object")
+			}
+			return nil, nil
+		},
+	}
+
+	check, _ := preflight.NewRegistry().Get(preflight.CheckMergeConflicts)
+	results := preflight.NewChecker(mockGit, "").
+		WithOperation(preflight.Operation{Source: "release/1.0.0", Targets: []string{"master", "develop", "missing"}}).
+		WithChecks(check).
+		RunAll(context.Background())
+
+	output := results.String()
+	if !strings.Contains(output, "✅ 'release/1.0.0' merges cleanly into 'master'") {
+		t.Errorf("Expected master to pass, got:\n%s", output)
+	}
+	if !strings.Contains(output, "❌ 'release/1.0.0' merges cleanly into 'develop'") ||
+		!strings.Contains(output, "conflicts in VERSION, go.mod") {
+		t.Errorf("Expected develop conflicts, got:\n%s", output)
+	}
+	if strings.Contains(output, "'missing'") {
+		t.Errorf("Missing targets should be skipped, got:\n%s", output)
+	}
+
+	// Only an unknown --write-tree blames the git version
+	results = preflight.NewChecker(mockGit, "").
+		WithOperation(preflight.Operation{Source: "release/1.0.0", Targets: []string{"old-git", "broken"}}).
+		WithChecks(check).
+		RunAll(context.Background())
+	if len(results) != 2 || results[0].Hint != "Merge prediction needs git 2.38 or newer" || results[1].Hint != "" {
+		t.Errorf("Expected the git version hint for old-git only, got:\n%s", results)
+	}
+
+	// A missing source is left to the command
+	results = preflight.NewChecker(mockGit, "").
+		WithOperation(preflight.Operation{Source: "missing", Targets: []string{"develop"}}).
+		WithChecks(check).
+		RunAll(context.Background())
+	if len(results) != 0 {
+		t.Errorf("Expected a missing source to be skipped, got:\n%s", results)
+	}
+}
+
+func TestChecker_RepositoryState(t *testing.T) {
+	tests := []struct {
+		name   string
+		git    *testdata.MockGit
+		strict bool
+		failed string
+		error  string
+	}{
+		{
+			name: "detached HEAD",
+			git: &testdata.MockGit{CurrentBranchFunc: func(ctx context.Context) (string, error) {
+				return "", nil
+			}},
+			failed: "HEAD is on a branch",
+			error:  "HEAD is detached",
+		},
+		{
+			name: "rebase in progress",
+			git: &testdata.MockGit{InProgressFunc: func(ctx context.Context) (string, error) {
+				return "rebase", nil
+			}},
+			failed: "No rebase, merge or cherry-pick in progress",
+			error:  "a rebase is in progress",
+		},
+		{
+			name: "unresolved conflicts",
+			git: &testdata.MockGit{UnmergedFilesFunc: func(ctx context.Context) ([]string, error) {
+				return []string{"a.go", "b.go"}, nil
+			}},
+			failed: "No unresolved conflicts",
+			error:  "conflicts in a.go, b.go",
+		},
+		{
+			name: "dirty submodule",
+			git: &testdata.MockGit{DirtySubmodulesFunc: func(ctx context.Context) ([]string, error) {
+				return []string{"lib"}, nil
+			}},
+			failed: "Submodules are clean",
+			error:  "modified submodules: lib",
+		},
+		{
+			name: "untracked files in strict mode",
+			git: &testdata.MockGit{UntrackedFilesFunc: func(ctx context.Context) ([]string, error) {
+				return []string{"1", "2", "3", "4", "5", "6", "7"}, nil
+			}},
+			strict: true,
+			failed: "No untracked files",
+			error:  "untracked: 1, 2, 3, 4, 5 and 2 more",
+		},
+		{
+			name: "missing user.email",
+			git: &testdata.MockGit{ConfigValueFunc: func(ctx context.Context, key string) (string, error) {
+				return "", nil
+			}},
+			failed: "git user.email is set",
+		},
+		{
+			name: "shallow clone",
+			git: &testdata.MockGit{IsShallowFunc: func(ctx context.Context) (bool, error) {
+				return true, nil
+			}},
+			failed: "Repository is not a shallow clone",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			results := preflight.NewChecker(tt.git, "").
+				WithOperation(preflight.Operation{Targets: []string{"develop"}, Strict: tt.strict}).
+				RunAll(context.Background())
+
+			var failed []preflight.Result
+			for _, r := range results {
+				if !r.Passed {
+					failed = append(failed, r)
+				}
+			}
+			if len(failed) != 1 || failed[0].Name != tt.failed {
+				t.Fatalf("Expected only %q to fail, got:\n%s", tt.failed, results.String())
+			}
+			if failed[0].Error != tt.error {
+				t.Errorf("Error = %q, want %q", failed[0].Error, tt.error)
+			}
+			if failed[0].Hint == "" {
+				t.Error("Expected a hint")
+			}
+		})
+	}
+
+	t.Run("untracked files outside strict mode", func(t *testing.T) {
+		mockGit := &testdata.MockGit{UntrackedFilesFunc: func(ctx context.Context) ([]string, error) {
+			return []string{"notes.txt"}, nil
+		}}
+		results := preflight.NewChecker(mockGit, "develop").RunAll(context.Background())
+		if results.HasErrors() || strings.Contains(results.String(), "No untracked files") {
+			t.Errorf("Untracked files should not be checked, got:\n%s", results.String())
+		}
+	})
+}
+
+func TestChecker_WorkflowRules(t *testing.T) {
+	mockGit := &testdata.MockGit{
+		AheadBehindFunc: func(ctx context.Context, branch, base string) (int, int, error) {
+			if base == "develop" {
+				return 3, 2, nil
+			}
+			return 3, 0, nil
+		},
+	}
+	registry := preflight.NewRegistry()
+	linear, _ := registry.Get(preflight.CheckLinearHistory)
+
+	op := preflight.Operation{Source: "feature/x", Targets: []string{"master", "develop"}, Remote: "origin"}
+	output := preflight.NewChecker(mockGit, "").WithOperation(op).WithChecks(linear).RunAll(context.Background()).String()
+	if !strings.Contains(output, "✅ 'feature/x' is rebased on 'master'") ||
+		!strings.Contains(output, "feature/x is missing 2 commits of develop") ||
+		!strings.Contains(output, "git rebase develop feature/x") {
+		t.Errorf("Unexpected linear history results:\n%s", output)
+	}
+}
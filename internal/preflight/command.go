@@ -0,0 +1,94 @@
+package preflight
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"os/exec"
+	"strings"
+	"time"
+)
+
+// DefaultCommandTimeout bounds a CommandCheck without its own timeout
+const DefaultCommandTimeout = 10 * time.Minute
+
+// maxOutputLines is how much of a failing command's output is reported
+const maxOutputLines = 5
+
+// CommandCheck is a project-defined check that runs a shell command, such as
+// "make test". It passes if the command exits with status 0.
+type CommandCheck struct {
+	CheckName string        // name used in config and shown in the results
+	Command   string        // run with sh -c in the working directory
+	Hint      string        // shown when the command fails
+	Timeout   time.Duration // default: DefaultCommandTimeout
+	Dir       string        // working directory; empty means the current one
+}
+
+// Name returns the name of the check
+func (c *CommandCheck) Name() string {
+	return c.CheckName
+}
+
+// Run executes the command. It is bounded by its own timeout rather than the
+// caller's, since test suites usually outlast git operations. The operation
+// is passed in GZFLOW_CHECK_OPERATION, GZFLOW_CHECK_SOURCE and
+// GZFLOW_CHECK_TARGETS (space separated).
+func (c *CommandCheck) Run(ctx context.Context, git GitExecutor, op Operation) Results {
+	timeout := c.Timeout
+	if timeout <= 0 {
+		timeout = DefaultCommandTimeout
+	}
+	ctx, cancel := context.WithTimeout(context.WithoutCancel(ctx), timeout)
+	defer cancel()
+
+	cmd := exec.CommandContext(ctx, "sh", "-c", c.Command)
+	cmd.Dir = c.Dir
+	cmd.WaitDelay = time.Second // don't wait on children still holding the output open
+	cmd.Env = append(cmd.Environ(),
+		"GZFLOW_CHECK_OPERATION="+op.Name,
+		"GZFLOW_CHECK_SOURCE="+op.Source,
+		"GZFLOW_CHECK_TARGETS="+strings.Join(op.Targets, " "),
+	)
+	var out bytes.Buffer
+	cmd.Stdout = &out
+	cmd.Stderr = &out
+
+	err := cmd.Run()
+	if err == nil {
+		return Results{{Name: c.CheckName, Passed: true}}
+	}
+
+	result := Result{Name: c.CheckName, Passed: false, Hint: c.Hint}
+	var exitErr *exec.ExitError
+	switch {
+	case errors.Is(ctx.Err(), context.DeadlineExceeded):
+		result.Error = fmt.Sprintf("'%s' timed out after %s", c.Command, timeout)
+	case errors.As(err, &exitErr):
+		result.Error = fmt.Sprintf("'%s' exited with status %d", c.Command, exitErr.ExitCode())
+	default:
+		result.Error = fmt.Sprintf("'%s' failed: %v", c.Command, err)
+	}
+	if tail := lastLines(out.String(), maxOutputLines); tail != "" {
+		result.Error += ":\n" + tail
+	}
+	if result.Hint == "" {
+		result.Hint = fmt.Sprintf("Run '%s' to see the full output", c.Command)
+	}
+	return Results{result}
+}
+
+// lastLines returns the last n non-empty lines of s
+func lastLines(s string, n int) string {
+	var lines []string
+	for _, line := range strings.Split(strings.TrimSpace(s), "\n") {
+		if strings.TrimSpace(line) != "" {
+			lines = append(lines, line)
+		}
+	}
+	if len(lines) > n {
+		lines = lines[len(lines)-n:]
+	}
+	return strings.Join(lines, "\n")
+}
@@ -0,0 +1,79 @@
+package preflight_test
+
+import (
+	"context"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/gizzahub/gzh-cli-gitflow/internal/preflight"
+	"github.com/gizzahub/gzh-cli-gitflow/internal/preflight/testdata"
+)
+
+func TestCommandCheck(t *testing.T) {
+	ctx := context.Background()
+	op := preflight.Operation{Name: "release finish", Source: "release/1.0.0", Targets: []string{"master", "develop"}}
+
+	t.Run("passes on exit 0", func(t *testing.T) {
+		check := &preflight.CommandCheck{CheckName: "make test must pass", Command: "true"}
+		results := check.Run(ctx, &testdata.MockGit{}, op)
+		if len(results) != 1 || !results[0].Passed || results[0].Name != "make test must pass" {
+			t.Errorf("Unexpected results: %+v", results)
+		}
+	})
+
+	t.Run("reports the exit status and output tail", func(t *testing.T) {
+		check := &preflight.CommandCheck{
+			CheckName: "no TODO in CHANGELOG",
+			Command:   "for i in 1 2 3 4 5 6 7; do echo line$i; done; exit 3",
+			Hint:      "Finish the changelog",
+		}
+		results := check.Run(ctx, &testdata.MockGit{}, op)
+		if len(results) != 1 || results[0].Passed {
+			t.Fatalf("Expected a failure, got %+v", results)
+		}
+		r := results[0]
+		if !strings.Contains(r.Error, "exited with status 3") || !strings.Contains(r.Error, "line7") || strings.Contains(r.Error, "line2") {
+			t.Errorf("Unexpected error: %q", r.Error)
+		}
+		if r.Hint != "Finish the changelog" {
+			t.Errorf("Hint = %q", r.Hint)
+		}
+		if output := results.String(); !strings.Contains(output, "\n            line7") {
+			t.Errorf("Output lines should be indented:\n%s", output)
+		}
+	})
+
+	t.Run("default hint", func(t *testing.T) {
+		check := &preflight.CommandCheck{CheckName: "lint", Command: "false"}
+		results := check.Run(ctx, &testdata.MockGit{}, op)
+		if results[0].Hint != "Run 'false' to see the full output" {
+			t.Errorf("Hint = %q", results[0].Hint)
+		}
+	})
+
+	t.Run("operation environment", func(t *testing.T) {
+		check := &preflight.CommandCheck{
+			CheckName: "env",
+			Command:   `test "$GZFLOW_CHECK_OPERATION|$GZFLOW_CHECK_SOURCE|$GZFLOW_CHECK_TARGETS" = "release finish|release/1.0.0|master develop"`,
+		}
+		if results := check.Run(ctx, &testdata.MockGit{}, op); !results[0].Passed {
+			t.Errorf("Unexpected results: %+v", results)
+		}
+	})
+
+	t.Run("own timeout outlives the caller's", func(t *testing.T) {
+		short, cancel := context.WithTimeout(ctx, 10*time.Millisecond)
+		defer cancel()
+		check := &preflight.CommandCheck{CheckName: "slow", Command: "sleep 0.2"}
+		if results := check.Run(short, &testdata.MockGit{}, op); !results[0].Passed {
+			t.Errorf("Unexpected results: %+v", results)
+		}
+
+		check = &preflight.CommandCheck{CheckName: "slow", Command: "sleep 5", Timeout: 50 * time.Millisecond}
+		results := check.Run(ctx, &testdata.MockGit{}, op)
+		if results[0].Passed || !strings.Contains(results[0].Error, "timed out after 50ms") {
+			t.Errorf("Unexpected results: %+v", results)
+		}
+	})
+}
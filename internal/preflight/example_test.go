@@ -25,7 +25,13 @@ func ExampleChecker_RunAll_success() {
 	fmt.Print(results.String())
 	// Output:
 	// Pre-flight checks:
+	//   ✅ HEAD is on a branch
+	//   ✅ No rebase, merge or cherry-pick in progress
+	//   ✅ No unresolved conflicts
 	//   ✅ Clean working tree
+	//   ✅ Submodules are clean
+	//   ✅ git user.email is set
+	//   ✅ Repository is not a shallow clone
 	//   ✅ Target branch 'develop' exists
 }
 
@@ -46,8 +52,14 @@ func ExampleChecker_RunAll_failure() {
 	fmt.Print(results.String())
 	// Output:
 	// Pre-flight checks:
+	//   ✅ HEAD is on a branch
+	//   ✅ No rebase, merge or cherry-pick in progress
+	//   ✅ No unresolved conflicts
 	//   ❌ Clean working tree
 	//      💡 Commit or stash your changes before finishing
+	//   ✅ Submodules are clean
+	//   ✅ git user.email is set
+	//   ✅ Repository is not a shallow clone
 	//   ❌ Target branch 'develop' exists
 	//      💡 Create branch 'develop' first or check your configuration
 }
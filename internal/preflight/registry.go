@@ -0,0 +1,143 @@
+package preflight
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+)
+
+// Operation describes the git-flow operation the checks validate
+type Operation struct {
+	Name    string   // e.g. "release finish"
+	Source  string   // flow branch being merged; empty skips the merge checks
+	Targets []string // branches merged into; the first one must exist
+	Remote  string   // remote to compare with; empty skips the up-to-date check
+	Fetch   bool     // fetch Remote before comparing
+	Strict  bool     // guardian strict mode: untracked files fail too
+}
+
+// Check is a single pre-flight check. A check returns one result per thing it
+// verified, or none if it does not apply to the operation.
+type Check interface {
+	Name() string
+	Run(ctx context.Context, git GitExecutor, op Operation) Results
+}
+
+// funcCheck adapts a function to the Check interface
+type funcCheck struct {
+	name string
+	run  func(ctx context.Context, git GitExecutor, op Operation) Results
+}
+
+func (c funcCheck) Name() string { return c.name }
+
+func (c funcCheck) Run(ctx context.Context, git GitExecutor, op Operation) Results {
+	return c.run(ctx, git, op)
+}
+
+// NewCheck creates a Check from a function
+func NewCheck(name string, run func(ctx context.Context, git GitExecutor, op Operation) Results) Check {
+	return funcCheck{name: name, run: run}
+}
+
+// advisoryCheck reports the failures of a check as warnings
+type advisoryCheck struct {
+	Check
+}
+
+func (c advisoryCheck) Run(ctx context.Context, git GitExecutor, op Operation) Results {
+	results := c.Check.Run(ctx, git, op)
+	for i := range results {
+		if !results[i].Passed {
+			results[i].Warning = true
+		}
+	}
+	return results
+}
+
+// Advisory wraps a check so that its failures are warnings that do not block
+// the operation, e.g. for a guardian rule in permissive mode
+func Advisory(check Check) Check {
+	return advisoryCheck{check}
+}
+
+// Registry holds the available checks in run order
+type Registry struct {
+	checks []Check
+}
+
+// NewRegistry creates a registry with the built-in checks
+func NewRegistry() *Registry {
+	r := &Registry{}
+	for _, check := range Builtin() {
+		// Built-in names are unique
+		_ = r.Register(check)
+	}
+	return r
+}
+
+// Register adds a check after the registered ones. Names must be unique.
+func (r *Registry) Register(check Check) error {
+	if check.Name() == "" {
+		return fmt.Errorf("check name must not be empty")
+	}
+	if _, ok := r.Get(check.Name()); ok {
+		return fmt.Errorf("check '%s' is already registered", check.Name())
+	}
+	r.checks = append(r.checks, check)
+	return nil
+}
+
+// Get returns the check with the given name
+func (r *Registry) Get(name string) (Check, bool) {
+	for _, check := range r.checks {
+		if check.Name() == name {
+			return check, true
+		}
+	}
+	return nil, false
+}
+
+// Names returns the names of all registered checks, sorted
+func (r *Registry) Names() []string {
+	names := make([]string, len(r.checks))
+	for i, check := range r.checks {
+		names[i] = check.Name()
+	}
+	sort.Strings(names)
+	return names
+}
+
+// Select returns the registered checks in run order, leaving out the
+// disabled ones. A name in enable overrides the same name in disable.
+// Unknown names are an error.
+func (r *Registry) Select(enable, disable []string) ([]Check, error) {
+	var unknown []string
+	enabled := map[string]bool{}
+	for _, name := range enable {
+		if _, ok := r.Get(name); !ok {
+			unknown = append(unknown, name)
+		}
+		enabled[name] = true
+	}
+	disabled := map[string]bool{}
+	for _, name := range disable {
+		if _, ok := r.Get(name); !ok {
+			unknown = append(unknown, name)
+		}
+		disabled[name] = true
+	}
+	if len(unknown) > 0 {
+		return nil, fmt.Errorf("unknown pre-flight check: %s (known: %s)", strings.Join(unknown, ", "), strings.Join(r.Names(), ", "))
+	}
+
+	var checks []Check
+	for _, check := range r.checks {
+		if disabled[check.Name()] && !enabled[check.Name()] {
+			continue
+		}
+		checks = append(checks, check)
+	}
+	return checks, nil
+}
@@ -0,0 +1,123 @@
+package preflight_test
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/gizzahub/gzh-cli-gitflow/internal/preflight"
+	"github.com/gizzahub/gzh-cli-gitflow/internal/preflight/testdata"
+)
+
+// names returns the names of checks in order
+func names(checks []preflight.Check) string {
+	out := make([]string, len(checks))
+	for i, c := range checks {
+		out[i] = c.Name()
+	}
+	return strings.Join(out, ",")
+}
+
+func TestRegistry_Register(t *testing.T) {
+	r := preflight.NewRegistry()
+	want := "clean-tree,detached-head,dirty-submodules,linear-history,merge-conflicts,operation-in-progress," +
+		"shallow-clone,target-exists,unresolved-conflicts,untracked-files,up-to-date,user-email"
+	if got := strings.Join(r.Names(), ","); got != want {
+		t.Errorf("Names() = %s", got)
+	}
+
+	custom := preflight.NewCheck("changelog", func(ctx context.Context, git preflight.GitExecutor, op preflight.Operation) preflight.Results {
+		return preflight.Results{{Name: "CHANGELOG has no TODO", Passed: true}}
+	})
+	if err := r.Register(custom); err != nil {
+		t.Fatalf("Register failed: %v", err)
+	}
+	if err := r.Register(custom); err == nil {
+		t.Error("Register should reject a duplicate name")
+	}
+	if err := r.Register(preflight.NewCheck("", nil)); err == nil {
+		t.Error("Register should reject an empty name")
+	}
+	if _, ok := r.Get("changelog"); !ok {
+		t.Error("Get should find the registered check")
+	}
+}
+
+func TestRegistry_Select(t *testing.T) {
+	r := preflight.NewRegistry()
+	all := "detached-head,operation-in-progress,unresolved-conflicts,clean-tree,dirty-submodules,untracked-files," +
+		"user-email,shallow-clone,target-exists,up-to-date,linear-history,merge-conflicts"
+
+	tests := []struct {
+		name    string
+		enable  []string
+		disable []string
+		want    string
+		wantErr string
+	}{
+		{"defaults", nil, nil, all, ""},
+		{"disable", nil, []string{"linear-history", "merge-conflicts"}, strings.TrimSuffix(all, ",linear-history,merge-conflicts"), ""},
+		{"enable overrides disable", []string{"up-to-date"}, []string{"up-to-date"}, all, ""},
+		{"unknown", []string{"lint"}, []string{"typo"}, "", "unknown pre-flight check: lint, typo"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			checks, err := r.Select(tt.enable, tt.disable)
+			if tt.wantErr != "" {
+				if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+					t.Errorf("Select error = %v, want %q", err, tt.wantErr)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("Select failed: %v", err)
+			}
+			if got := names(checks); got != tt.want {
+				t.Errorf("Select = %s, want %s", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestChecker_WithChecks(t *testing.T) {
+	var got preflight.Operation
+	check := preflight.NewCheck("record", func(ctx context.Context, git preflight.GitExecutor, op preflight.Operation) preflight.Results {
+		got = op
+		return preflight.Results{{Name: "Recorded", Passed: false, Hint: "custom hint"}}
+	})
+
+	op := preflight.Operation{Name: "release finish", Source: "release/1.0.0", Targets: []string{"master"}}
+	results := preflight.NewChecker(&testdata.MockGit{}, "").WithOperation(op).WithChecks(check).RunAll(context.Background())
+
+	if got.Name != "release finish" || got.Source != "release/1.0.0" {
+		t.Errorf("check received %+v", got)
+	}
+	if len(results) != 1 || !strings.Contains(results.String(), "💡 custom hint") {
+		t.Errorf("Unexpected results:\n%s", results)
+	}
+}
+
+func TestAdvisory(t *testing.T) {
+	mockGit := &testdata.MockGit{
+		IsCleanFunc: func(ctx context.Context) (bool, error) { return false, nil },
+	}
+	check, _ := preflight.NewRegistry().Get(preflight.CheckCleanTree)
+	target, _ := preflight.NewRegistry().Get(preflight.CheckTargetExists)
+
+	results := preflight.NewChecker(mockGit, "develop").
+		WithChecks(preflight.Advisory(check), preflight.Advisory(target)).
+		RunAll(context.Background())
+	if results.HasErrors() {
+		t.Errorf("Advisory failures should not be errors:\n%s", results.String())
+	}
+	warnings := results.Warnings()
+	if len(warnings) != 1 || warnings[0].Check != preflight.CheckCleanTree {
+		t.Errorf("Warnings() = %+v", warnings)
+	}
+	if !strings.Contains(results.String(), "⚠️  Clean working tree") {
+		t.Errorf("Expected a warning line:\n%s", results.String())
+	}
+	if results[1].Check != preflight.CheckTargetExists || !results[1].Passed {
+		t.Errorf("Passing results should be unchanged: %+v", results[1])
+	}
+}
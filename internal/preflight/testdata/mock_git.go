@@ -7,6 +7,19 @@ type MockGit struct {
 	IsCleanFunc       func(ctx context.Context) (bool, error)
 	BranchExistsFunc  func(ctx context.Context, branch string) (bool, error)
 	CurrentBranchFunc func(ctx context.Context) (string, error)
+
+	FetchFunc              func(ctx context.Context, remote string, branches ...string) error
+	RemoteBranchExistsFunc func(ctx context.Context, remote, branch string) (bool, error)
+	AheadBehindFunc        func(ctx context.Context, branch, base string) (int, int, error)
+	MergeConflictsFunc     func(ctx context.Context, branch, into string) ([]string, error)
+
+	InProgressFunc      func(ctx context.Context) (string, error)
+	ModifiedFilesFunc   func(ctx context.Context) ([]string, error)
+	UntrackedFilesFunc  func(ctx context.Context) ([]string, error)
+	UnmergedFilesFunc   func(ctx context.Context) ([]string, error)
+	DirtySubmodulesFunc func(ctx context.Context) ([]string, error)
+	IsShallowFunc       func(ctx context.Context) (bool, error)
+	ConfigValueFunc     func(ctx context.Context, key string) (string, error)
 }
 
 func (m *MockGit) IsClean(ctx context.Context) (bool, error) {
@@ -29,3 +42,85 @@ func (m *MockGit) CurrentBranch(ctx context.Context) (string, error) {
 	}
 	return "develop", nil
 }
+
+func (m *MockGit) Fetch(ctx context.Context, remote string, branches ...string) error {
+	if m.FetchFunc != nil {
+		return m.FetchFunc(ctx, remote, branches...)
+	}
+	return nil
+}
+
+func (m *MockGit) RemoteBranchExists(ctx context.Context, remote, branch string) (bool, error) {
+	if m.RemoteBranchExistsFunc != nil {
+		return m.RemoteBranchExistsFunc(ctx, remote, branch)
+	}
+	return true, nil
+}
+
+func (m *MockGit) AheadBehind(ctx context.Context, branch, base string) (int, int, error) {
+	if m.AheadBehindFunc != nil {
+		return m.AheadBehindFunc(ctx, branch, base)
+	}
+	return 0, 0, nil
+}
+
+func (m *MockGit) MergeConflicts(ctx context.Context, branch, into string) ([]string, error) {
+	if m.MergeConflictsFunc != nil {
+		return m.MergeConflictsFunc(ctx, branch, into)
+	}
+	return nil, nil
+}
+
+func (m *MockGit) InProgress(ctx context.Context) (string, error) {
+	if m.InProgressFunc != nil {
+		return m.InProgressFunc(ctx)
+	}
+	return "", nil
+}
+
+// ModifiedFiles defaults to one modified file when IsCleanFunc reports a
+// dirty tree, and none otherwise
+func (m *MockGit) ModifiedFiles(ctx context.Context) ([]string, error) {
+	if m.ModifiedFilesFunc != nil {
+		return m.ModifiedFilesFunc(ctx)
+	}
+	if clean, _ := m.IsClean(ctx); !clean {
+		return []string{"modified.txt"}, nil
+	}
+	return nil, nil
+}
+
+func (m *MockGit) UntrackedFiles(ctx context.Context) ([]string, error) {
+	if m.UntrackedFilesFunc != nil {
+		return m.UntrackedFilesFunc(ctx)
+	}
+	return nil, nil
+}
+
+func (m *MockGit) UnmergedFiles(ctx context.Context) ([]string, error) {
+	if m.UnmergedFilesFunc != nil {
+		return m.UnmergedFilesFunc(ctx)
+	}
+	return nil, nil
+}
+
+func (m *MockGit) DirtySubmodules(ctx context.Context) ([]string, error) {
+	if m.DirtySubmodulesFunc != nil {
+		return m.DirtySubmodulesFunc(ctx)
+	}
+	return nil, nil
+}
+
+func (m *MockGit) IsShallow(ctx context.Context) (bool, error) {
+	if m.IsShallowFunc != nil {
+		return m.IsShallowFunc(ctx)
+	}
+	return false, nil
+}
+
+func (m *MockGit) ConfigValue(ctx context.Context, key string) (string, error) {
+	if m.ConfigValueFunc != nil {
+		return m.ConfigValueFunc(ctx, key)
+	}
+	return "test@example.com", nil
+}
@@ -0,0 +1,79 @@
+package state
+
+import (
+	"bufio"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"time"
+)
+
+// auditFileName is the guardian audit log inside the gz-flow directory
+const auditFileName = "audit.log"
+
+// Violation is a guardian rule violation that was allowed to proceed
+type Violation struct {
+	Time      time.Time `json:"time"`
+	Operation string    `json:"operation"` // e.g. "feature start"
+	Rule      string    `json:"rule"`      // guardian rule or pre-flight check name
+	Branch    string    `json:"branch,omitempty"`
+	User      string    `json:"user,omitempty"` // git user.email
+	Message   string    `json:"message"`
+}
+
+// AuditPath returns the path of the audit log inside a .git directory
+func AuditPath(gitDir string) string {
+	return filepath.Join(Dir(gitDir), auditFileName)
+}
+
+// AppendAudit adds violations to the audit log, one JSON object per line
+func AppendAudit(gitDir string, violations ...Violation) error {
+	if err := os.MkdirAll(Dir(gitDir), 0o755); err != nil {
+		return fmt.Errorf("failed to create state directory: %w", err)
+	}
+
+	f, err := os.OpenFile(AuditPath(gitDir), os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0o644)
+	if err != nil {
+		return fmt.Errorf("failed to open audit log: %w", err)
+	}
+	defer f.Close()
+
+	for _, v := range violations {
+		data, err := json.Marshal(v)
+		if err != nil {
+			return fmt.Errorf("failed to marshal audit entry: %w", err)
+		}
+		if _, err := f.Write(append(data, '\n')); err != nil {
+			return fmt.Errorf("failed to write audit log: %w", err)
+		}
+	}
+	return f.Close()
+}
+
+// ReadAudit returns the logged violations, oldest first. A missing log is empty.
+func ReadAudit(gitDir string) ([]Violation, error) {
+	f, err := os.Open(AuditPath(gitDir))
+	if errors.Is(err, os.ErrNotExist) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to open audit log: %w", err)
+	}
+	defer f.Close()
+
+	var violations []Violation
+	scanner := bufio.NewScanner(f)
+	for scanner.Scan() {
+		var v Violation
+		if err := json.Unmarshal(scanner.Bytes(), &v); err != nil {
+			return nil, fmt.Errorf("failed to parse audit log: %w", err)
+		}
+		violations = append(violations, v)
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, fmt.Errorf("failed to read audit log: %w", err)
+	}
+	return violations, nil
+}
@@ -0,0 +1,32 @@
+package state
+
+import (
+	"testing"
+	"time"
+)
+
+func TestAppendReadAudit(t *testing.T) {
+	gitDir := t.TempDir()
+
+	violations, err := ReadAudit(gitDir)
+	if err != nil || violations != nil {
+		t.Fatalf("ReadAudit with no log = %v, %v", violations, err)
+	}
+
+	first := Violation{Time: time.Now().UTC(), Operation: "feature start", Rule: "naming", Branch: "feature/WIP", Message: "bad name"}
+	second := Violation{Time: time.Now().UTC(), Operation: "feature finish", Rule: "clean-tree", User: "dev@example.com", Message: "dirty"}
+	if err := AppendAudit(gitDir, first); err != nil {
+		t.Fatalf("AppendAudit failed: %v", err)
+	}
+	if err := AppendAudit(gitDir, second); err != nil {
+		t.Fatalf("AppendAudit failed: %v", err)
+	}
+
+	violations, err = ReadAudit(gitDir)
+	if err != nil {
+		t.Fatalf("ReadAudit failed: %v", err)
+	}
+	if len(violations) != 2 || violations[0].Rule != "naming" || violations[1].User != "dev@example.com" {
+		t.Errorf("ReadAudit = %+v", violations)
+	}
+}
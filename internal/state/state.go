@@ -0,0 +1,104 @@
+// Package state persists in-progress git-flow operations under .git/gz-flow/
+// so that an interrupted finish can be resumed or aborted, and keeps the
+// audit log of guardian violations allowed to proceed.
+package state
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"time"
+
+	"github.com/gizzahub/gzh-cli-gitflow/internal/gitcmd"
+)
+
+// fileName is the state file inside the gz-flow directory
+const fileName = "state.json"
+
+// State is a finish operation that has not completed yet
+type State struct {
+	Operation string      `json:"operation"` // e.g. "release finish"
+	Name      string      `json:"name"`      // feature name or version
+	Steps     gitcmd.Plan `json:"steps"`
+	Completed int         `json:"completed"` // number of steps already applied
+
+	// Steps run after the local steps succeed (options.push_after_finish).
+	// They are not rolled back; a failure leaves the local result in place.
+	Push gitcmd.Plan `json:"push,omitempty"`
+
+	// Snapshot taken before the first step, used by --abort
+	gitcmd.Transaction
+
+	StartedAt time.Time `json:"started_at"`
+}
+
+// Dir returns the gz-flow directory inside a .git directory
+func Dir(gitDir string) string {
+	return filepath.Join(gitDir, "gz-flow")
+}
+
+// Load reads the saved state. It returns nil without error if no operation is in progress.
+func Load(gitDir string) (*State, error) {
+	data, err := os.ReadFile(filepath.Join(Dir(gitDir), fileName))
+	if errors.Is(err, os.ErrNotExist) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to read operation state: %w", err)
+	}
+
+	var st State
+	if err := json.Unmarshal(data, &st); err != nil {
+		return nil, fmt.Errorf("failed to parse operation state: %w", err)
+	}
+	return &st, nil
+}
+
+// Save writes the state, replacing any previous one
+func (s *State) Save(gitDir string) error {
+	if err := os.MkdirAll(Dir(gitDir), 0o755); err != nil {
+		return fmt.Errorf("failed to create state directory: %w", err)
+	}
+
+	data, err := json.MarshalIndent(s, "", "  ")
+	if err != nil {
+		return fmt.Errorf("failed to marshal operation state: %w", err)
+	}
+
+	// Write then rename so an interrupted save never leaves a truncated file
+	path := filepath.Join(Dir(gitDir), fileName)
+	if err := os.WriteFile(path+".tmp", data, 0o644); err != nil {
+		return fmt.Errorf("failed to write operation state: %w", err)
+	}
+	if err := os.Rename(path+".tmp", path); err != nil {
+		return fmt.Errorf("failed to write operation state: %w", err)
+	}
+	return nil
+}
+
+// Clear removes the saved state
+func Clear(gitDir string) error {
+	err := os.Remove(filepath.Join(Dir(gitDir), fileName))
+	if err != nil && !errors.Is(err, os.ErrNotExist) {
+		return fmt.Errorf("failed to remove operation state: %w", err)
+	}
+	return nil
+}
+
+// Remaining returns the steps that have not been applied yet
+func (s *State) Remaining() gitcmd.Plan {
+	if s.Completed >= len(s.Steps) {
+		return nil
+	}
+	return s.Steps[s.Completed:]
+}
+
+// Current returns the next step to apply, or nil if all steps are done
+func (s *State) Current() *gitcmd.Step {
+	if s.Completed >= len(s.Steps) {
+		return nil
+	}
+	return &s.Steps[s.Completed]
+}
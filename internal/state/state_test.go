@@ -0,0 +1,66 @@
+package state
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/gizzahub/gzh-cli-gitflow/internal/gitcmd"
+)
+
+func TestSaveLoadClear(t *testing.T) {
+	gitDir := t.TempDir()
+
+	st, err := Load(gitDir)
+	if err != nil || st != nil {
+		t.Fatalf("Load with no state = %v, %v", st, err)
+	}
+
+	st = &State{
+		Operation: "release finish",
+		Name:      "1.0.0",
+		Steps: []gitcmd.Step{
+			{Op: gitcmd.OpCheckout, Branch: "master"},
+			{Op: gitcmd.OpMerge, Branch: "release/1.0.0", Into: "master", NoFF: true},
+		},
+		Completed:   1,
+		Transaction: gitcmd.Transaction{OriginalRefs: map[string]string{"master": "abc123"}},
+	}
+	if err := st.Save(gitDir); err != nil {
+		t.Fatalf("Save failed: %v", err)
+	}
+	if _, err := os.Stat(filepath.Join(gitDir, "gz-flow", "state.json")); err != nil {
+		t.Fatalf("state file not written: %v", err)
+	}
+
+	loaded, err := Load(gitDir)
+	if err != nil {
+		t.Fatalf("Load failed: %v", err)
+	}
+	if loaded.Operation != st.Operation || loaded.OriginalRefs["master"] != "abc123" {
+		t.Errorf("Load = %+v", loaded)
+	}
+	if cur := loaded.Current(); cur == nil || cur.Op != gitcmd.OpMerge || !cur.NoFF {
+		t.Errorf("Current() = %+v", cur)
+	}
+	if len(loaded.Remaining()) != 1 {
+		t.Errorf("Remaining() = %v", loaded.Remaining())
+	}
+
+	if err := Clear(gitDir); err != nil {
+		t.Fatalf("Clear failed: %v", err)
+	}
+	if st, _ := Load(gitDir); st != nil {
+		t.Error("state should be cleared")
+	}
+	if err := Clear(gitDir); err != nil {
+		t.Errorf("Clear without state failed: %v", err)
+	}
+}
+
+func TestCurrentWhenDone(t *testing.T) {
+	st := &State{Steps: []gitcmd.Step{{Op: gitcmd.OpCheckout, Branch: "develop"}}, Completed: 1}
+	if st.Current() != nil || st.Remaining() != nil {
+		t.Error("a completed state has no current step")
+	}
+}
@@ -0,0 +1,82 @@
+package config
+
+import "strings"
+
+// BranchType identifies the role of a branch in the git-flow model
+type BranchType string
+
+const (
+	BranchMaster  BranchType = "master"
+	BranchDevelop BranchType = "develop"
+	BranchFeature BranchType = "feature"
+	BranchRelease BranchType = "release"
+	BranchHotfix  BranchType = "hotfix"
+	BranchOther   BranchType = "other"
+)
+
+// FlowTypes lists the branch types that are created with a prefix
+var FlowTypes = []BranchType{BranchFeature, BranchRelease, BranchHotfix}
+
+// ClassifyBranch returns the type of a branch and its name without the flow prefix
+func (c *Config) ClassifyBranch(branch string) (BranchType, string) {
+	switch branch {
+	case c.Branches.Master:
+		return BranchMaster, branch
+	case c.Branches.Develop:
+		return BranchDevelop, branch
+	}
+
+	for _, t := range FlowTypes {
+		prefix := c.Prefix(t)
+		if prefix != "" && strings.HasPrefix(branch, prefix) && len(branch) > len(prefix) {
+			return t, strings.TrimPrefix(branch, prefix)
+		}
+	}
+
+	return BranchOther, branch
+}
+
+// Prefix returns the configured prefix for a flow branch type
+func (c *Config) Prefix(t BranchType) string {
+	switch t {
+	case BranchFeature:
+		return c.Prefixes.Feature
+	case BranchRelease:
+		return c.Prefixes.Release
+	case BranchHotfix:
+		return c.Prefixes.Hotfix
+	}
+	return ""
+}
+
+// BaseBranch returns the branch a flow branch type is started from
+func (c *Config) BaseBranch(t BranchType) string {
+	switch t {
+	case BranchFeature, BranchRelease:
+		return c.Branches.Develop
+	case BranchHotfix:
+		return c.Branches.Master
+	}
+	return ""
+}
+
+// FinishTarget returns the branch a flow branch type is merged into on finish
+func (c *Config) FinishTarget(t BranchType) string {
+	switch t {
+	case BranchFeature:
+		return c.Branches.Develop
+	case BranchRelease, BranchHotfix:
+		return c.Branches.Master
+	}
+	return ""
+}
+
+// ParseBranchType converts a user-supplied type name into a flow BranchType
+func ParseBranchType(name string) (BranchType, bool) {
+	for _, t := range FlowTypes {
+		if string(t) == name {
+			return t, true
+		}
+	}
+	return "", false
+}
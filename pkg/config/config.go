@@ -4,16 +4,18 @@ import (
 	"fmt"
 	"os"
 	"path/filepath"
+	"strings"
 
 	"gopkg.in/yaml.v3"
 )
 
 // Config represents the complete gitflow configuration
 type Config struct {
-	Branches BranchConfig   `yaml:"branches"`
-	Prefixes PrefixConfig   `yaml:"prefixes"`
-	Options  OptionsConfig  `yaml:"options"`
-	Guardian GuardianConfig `yaml:"guardian"`
+	Branches  BranchConfig    `yaml:"branches"`
+	Prefixes  PrefixConfig    `yaml:"prefixes"`
+	Options   OptionsConfig   `yaml:"options"`
+	Guardian  GuardianConfig  `yaml:"guardian"`
+	Preflight PreflightConfig `yaml:"preflight"`
 }
 
 // BranchConfig defines the main branch names
@@ -35,6 +37,7 @@ type OptionsConfig struct {
 	PushAfterFinish         bool   `yaml:"push_after_finish"`
 	TagFormat               string `yaml:"tag_format"`
 	RequireCleanTree        bool   `yaml:"require_clean_tree"`
+	Remote                  string `yaml:"remote"` // remote used by publish, track and pull
 }
 
 // Default returns a Config with default gitflow settings
@@ -54,6 +57,7 @@ func Default() *Config {
 			PushAfterFinish:         false,
 			TagFormat:               "v%s",
 			RequireCleanTree:        true,
+			Remote:                  "origin",
 		},
 		Guardian: GuardianConfig{
 			Enabled: false,
@@ -70,6 +74,10 @@ func Default() *Config {
 				RequireLinearHistory: false,
 			},
 		},
+		Preflight: PreflightConfig{
+			Fetch:    true,
+			Disabled: []string{},
+		},
 	}
 }
 
@@ -88,28 +96,37 @@ func Load(path string) (*Config, error) {
 	return cfg, nil
 }
 
-// LoadFromDir loads configuration from a directory
-// Checks for local .gzflow.yaml first, then falls back to global ~/.gz/gitflow
+// LoadFromDir loads configuration for a directory by merging, field by field,
+// the defaults, the global ~/.gz/gitflow, the local .gzflow.yaml and
+// GZFLOW_* environment variables (later layers win).
 func LoadFromDir(dir string) (*Config, error) {
-	// Try local config first
-	localPath := filepath.Join(dir, ".gzflow.yaml")
-	if _, err := os.Stat(localPath); err == nil {
-		return Load(localPath)
-	}
+	cfg, _, err := LoadLayered(LoadOptions{Dir: dir, Environ: os.Environ()})
+	return cfg, err
+}
 
-	// Try global config
-	homeDir, err := os.UserHomeDir()
-	if err != nil {
-		return Default(), nil
+// Validate checks that the configuration is usable
+func (c *Config) Validate() error {
+	if c.Branches.Master == "" || c.Branches.Develop == "" {
+		return fmt.Errorf("branches.master and branches.develop must be set")
 	}
-
-	globalPath := filepath.Join(homeDir, ".gz", "gitflow")
-	if _, err := os.Stat(globalPath); err == nil {
-		return Load(globalPath)
+	if c.Branches.Master == c.Branches.Develop {
+		return fmt.Errorf("branches.master and branches.develop must differ (both are '%s')", c.Branches.Master)
 	}
-
-	// No config found, return default
-	return Default(), nil
+	if c.Options.Remote == "" {
+		return fmt.Errorf("options.remote must be set")
+	}
+	if strings.Count(c.Options.TagFormat, "%s") != 1 {
+		return fmt.Errorf("options.tag_format must contain exactly one %%s (got '%s')", c.Options.TagFormat)
+	}
+	if err := c.Guardian.validate(); err != nil {
+		return err
+	}
+	// Pushing develop and master after a finish is exactly the direct push the rule forbids
+	if c.Guardian.Enabled && c.Guardian.Workflow.PreventDirectPush && c.Options.PushAfterFinish &&
+		c.Guardian.SeverityOf(RulePreventDirectPush) != SeverityOff {
+		return fmt.Errorf("options.push_after_finish and guardian.workflow.prevent_direct_push cannot both be on: finish would push develop and master directly")
+	}
+	return c.Preflight.validate()
 }
 
 // Save saves configuration to a YAML file
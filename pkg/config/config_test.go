@@ -1,9 +1,12 @@
 package config
 
 import (
+	"errors"
 	"os"
 	"path/filepath"
+	"strings"
 	"testing"
+	"time"
 )
 
 func TestDefault(t *testing.T) {
@@ -145,16 +148,36 @@ func TestGuardianValidation(t *testing.T) {
 	}
 }
 
+func TestGuardianConfig_SeverityOf(t *testing.T) {
+	gc := Default().Guardian
+	gc.Severity.RequireLinearHistory = SeverityWarn
+
+	if got := gc.SeverityOf(RuleNaming); got != SeverityError {
+		t.Errorf("strict SeverityOf(naming) = %s, want error", got)
+	}
+	if got := gc.SeverityOf(RuleRequireLinearHistory); got != SeverityWarn {
+		t.Errorf("SeverityOf(require_linear_history) = %s, want warn", got)
+	}
+
+	gc.Mode = "permissive"
+	gc.Severity.RequireUpToDate = SeverityError
+	if got := gc.SeverityOf(RuleRequireCleanTree); got != SeverityWarn {
+		t.Errorf("permissive SeverityOf(require_clean_tree) = %s, want warn", got)
+	}
+	if got := gc.SeverityOf(RuleRequireUpToDate); got != SeverityError {
+		t.Errorf("SeverityOf(require_up_to_date) = %s, want error", got)
+	}
+}
+
 func TestLoadFromDir(t *testing.T) {
 	t.Run("local config exists", func(t *testing.T) {
 		dir := t.TempDir()
 
-		// Create local config
-		localCfg := &Config{
-			Branches: BranchConfig{
-				Master:  "main",
-				Develop: "dev",
-			},
+		// Create local config; a saved file holds every key, so start from valid values
+		localCfg := Default()
+		localCfg.Branches = BranchConfig{
+			Master:  "main",
+			Develop: "dev",
 		}
 		localPath := filepath.Join(dir, ".gzflow.yaml")
 		if err := localCfg.Save(localPath); err != nil {
@@ -261,3 +284,387 @@ func TestGuardianConfig_ValidateBranchName(t *testing.T) {
 		}
 	})
 }
+
+func TestClassifyBranch(t *testing.T) {
+	cfg := Default()
+
+	tests := []struct {
+		branch   string
+		wantType BranchType
+		wantName string
+	}{
+		{"master", BranchMaster, "master"},
+		{"develop", BranchDevelop, "develop"},
+		{"feature/login", BranchFeature, "login"},
+		{"release/1.0.0", BranchRelease, "1.0.0"},
+		{"hotfix/1.0.1", BranchHotfix, "1.0.1"},
+		{"feature/", BranchOther, "feature/"},
+		{"bugfix/x", BranchOther, "bugfix/x"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.branch, func(t *testing.T) {
+			gotType, gotName := cfg.ClassifyBranch(tt.branch)
+			if gotType != tt.wantType || gotName != tt.wantName {
+				t.Errorf("ClassifyBranch(%q) = (%s, %s), want (%s, %s)",
+					tt.branch, gotType, gotName, tt.wantType, tt.wantName)
+			}
+		})
+	}
+
+	if base := cfg.BaseBranch(BranchHotfix); base != "master" {
+		t.Errorf("BaseBranch(hotfix) = %q, want master", base)
+	}
+	if base := cfg.BaseBranch(BranchFeature); base != "develop" {
+		t.Errorf("BaseBranch(feature) = %q, want develop", base)
+	}
+}
+
+func TestGetSet(t *testing.T) {
+	cfg := Default()
+
+	if err := cfg.Set("branches.master", "main"); err != nil {
+		t.Fatalf("Set failed: %v", err)
+	}
+	if err := cfg.Set("options.push_after_finish", "true"); err != nil {
+		t.Fatalf("Set failed: %v", err)
+	}
+	if err := cfg.Set("guardian.naming.max_length", "30"); err != nil {
+		t.Fatalf("Set failed: %v", err)
+	}
+	if err := cfg.Set("guardian.naming.forbidden", "wip, tmp"); err != nil {
+		t.Fatalf("Set failed: %v", err)
+	}
+
+	tests := map[string]string{
+		"branches.master":            "main",
+		"options.push_after_finish":  "true",
+		"guardian.naming.max_length": "30",
+		"guardian.naming.forbidden":  "wip,tmp",
+		"options.tag_format":         "v%s",
+	}
+	for key, want := range tests {
+		got, err := cfg.Get(key)
+		if err != nil {
+			t.Errorf("Get(%q) failed: %v", key, err)
+			continue
+		}
+		if got != want {
+			t.Errorf("Get(%q) = %q, want %q", key, got, want)
+		}
+	}
+
+	if err := cfg.Set("options.push_after_finish", "yes"); err == nil {
+		t.Error("Set should reject a non-boolean value for a bool key")
+	}
+	if err := cfg.Set("guardian.naming.max_length", "ten"); err == nil {
+		t.Error("Set should reject a non-integer value for an int key")
+	}
+	if _, err := cfg.Get("branches.unknown"); err == nil {
+		t.Error("Get should reject unknown keys")
+	}
+	if err := cfg.Set("guardian.naming", "x"); err == nil {
+		t.Error("Set should reject non-leaf keys")
+	}
+}
+
+func TestSetInFile(t *testing.T) {
+	path := filepath.Join(t.TempDir(), ".gzflow.yaml")
+	initial := "# team settings\nbranches:\n    master: main # production\n"
+	if err := os.WriteFile(path, []byte(initial), 0o644); err != nil {
+		t.Fatal(err)
+	}
+
+	if err := SetInFile(path, "options.push_after_finish", "true"); err != nil {
+		t.Fatalf("SetInFile failed: %v", err)
+	}
+
+	data, _ := os.ReadFile(path)
+	if !strings.Contains(string(data), "# production") {
+		t.Errorf("SetInFile should keep comments, got:\n%s", data)
+	}
+	if strings.Contains(string(data), "develop") {
+		t.Errorf("SetInFile should not write unrelated defaults, got:\n%s", data)
+	}
+
+	cfg, err := Load(path)
+	if err != nil {
+		t.Fatalf("Load failed: %v", err)
+	}
+	if cfg.Branches.Master != "main" || !cfg.Options.PushAfterFinish {
+		t.Errorf("Unexpected config after set: %+v", cfg)
+	}
+
+	if err := SetInFile(path, "options.push_after_finish", "maybe"); err == nil {
+		t.Error("SetInFile should reject type mismatches")
+	}
+	if err := SetInFile(path, "branches.develop", "main"); err == nil {
+		t.Error("SetInFile should reject configurations that fail validation")
+	}
+
+	if err := UnsetInFile(path, "options.push_after_finish"); err != nil {
+		t.Fatalf("UnsetInFile failed: %v", err)
+	}
+	data, _ = os.ReadFile(path)
+	if strings.Contains(string(data), "options") {
+		t.Errorf("UnsetInFile should prune empty sections, got:\n%s", data)
+	}
+	if err := UnsetInFile(path, "options.push_after_finish"); err == nil {
+		t.Error("UnsetInFile should fail for keys that are not set")
+	}
+}
+
+func TestLoadLayered(t *testing.T) {
+	home := t.TempDir()
+	t.Setenv("HOME", home)
+	dir := t.TempDir()
+
+	globalPath := filepath.Join(home, ".gz", "gitflow")
+	if err := SetInFile(globalPath, "options.push_after_finish", "true"); err != nil {
+		t.Fatal(err)
+	}
+	if err := SetInFile(globalPath, "options.tag_format", "release-%s"); err != nil {
+		t.Fatal(err)
+	}
+	if err := SetInFile(globalPath, "branches.develop", "dev"); err != nil {
+		t.Fatal(err)
+	}
+
+	localPath := filepath.Join(dir, LocalFileName)
+	if err := SetInFile(localPath, "branches.master", "main"); err != nil {
+		t.Fatal(err)
+	}
+	if err := SetInFile(localPath, "options.tag_format", "v%s"); err != nil {
+		t.Fatal(err)
+	}
+
+	flagPath := filepath.Join(t.TempDir(), "ci.yaml")
+	if err := SetInFile(flagPath, "guardian.enabled", "true"); err != nil {
+		t.Fatal(err)
+	}
+
+	t.Run("project merges with global", func(t *testing.T) {
+		cfg, err := LoadFromDir(dir)
+		if err != nil {
+			t.Fatalf("LoadFromDir failed: %v", err)
+		}
+		if cfg.Branches.Master != "main" {
+			t.Errorf("branches.master = %q, want main (project)", cfg.Branches.Master)
+		}
+		if cfg.Branches.Develop != "dev" {
+			t.Errorf("branches.develop = %q, want dev (global)", cfg.Branches.Develop)
+		}
+		if !cfg.Options.PushAfterFinish {
+			t.Error("options.push_after_finish should come from global")
+		}
+		if cfg.Options.TagFormat != "v%s" {
+			t.Errorf("options.tag_format = %q, want v%%s (project overrides global)", cfg.Options.TagFormat)
+		}
+		if !cfg.Options.DeleteBranchAfterFinish {
+			t.Error("options.delete_branch_after_finish should keep its default")
+		}
+	})
+
+	t.Run("env and flag layers with origins", func(t *testing.T) {
+		cfg, origins, err := LoadLayered(LoadOptions{
+			Dir:        dir,
+			Environ:    []string{"GZFLOW_BRANCHES_MASTER=trunk", "UNRELATED=1"},
+			ConfigFile: flagPath,
+		})
+		if err != nil {
+			t.Fatalf("LoadLayered failed: %v", err)
+		}
+		if cfg.Branches.Master != "trunk" {
+			t.Errorf("branches.master = %q, want trunk (env)", cfg.Branches.Master)
+		}
+		if !cfg.Guardian.Enabled {
+			t.Error("guardian.enabled should come from the --config file")
+		}
+
+		want := map[string]Origin{
+			"branches.master":                    {Layer: LayerEnv, Source: "GZFLOW_BRANCHES_MASTER"},
+			"branches.develop":                   {Layer: LayerGlobal, Source: globalPath},
+			"options.tag_format":                 {Layer: LayerProject, Source: localPath},
+			"guardian.enabled":                   {Layer: LayerFlag, Source: flagPath},
+			"options.delete_branch_after_finish": {Layer: LayerDefault},
+		}
+		for key, origin := range want {
+			if origins[key] != origin {
+				t.Errorf("origin of %s = %v, want %v", key, origins[key], origin)
+			}
+		}
+	})
+
+	t.Run("invalid env value", func(t *testing.T) {
+		_, _, err := LoadLayered(LoadOptions{Environ: []string{"GZFLOW_OPTIONS_PUSH_AFTER_FINISH=yes"}})
+		if err == nil {
+			t.Error("Expected error for invalid boolean in environment")
+		}
+	})
+
+	t.Run("invalid merged values name their origin", func(t *testing.T) {
+		tests := []struct {
+			name    string
+			environ []string
+			want    string
+		}{
+			{
+				name:    "develop equals master",
+				environ: []string{"GZFLOW_BRANCHES_DEVELOP=main"},
+				want:    "branches.master and branches.develop must differ (both are 'main') (branches.master from project:" + localPath + ", branches.develop from env:GZFLOW_BRANCHES_DEVELOP)",
+			},
+			{
+				name:    "tag format without placeholder",
+				environ: []string{"GZFLOW_OPTIONS_TAG_FORMAT=latest"},
+				want:    "options.tag_format must contain exactly one %s (got 'latest') (options.tag_format from env:GZFLOW_OPTIONS_TAG_FORMAT)",
+			},
+		}
+		for _, tt := range tests {
+			t.Run(tt.name, func(t *testing.T) {
+				_, _, err := LoadLayered(LoadOptions{Dir: dir, Environ: tt.environ})
+				var invalid *InvalidError
+				if !errors.As(err, &invalid) {
+					t.Fatalf("Expected an *InvalidError, got %v", err)
+				}
+				if got := err.Error(); got != "invalid configuration: "+tt.want {
+					t.Errorf("error = %q\nwant %q", got, "invalid configuration: "+tt.want)
+				}
+			})
+		}
+	})
+
+	t.Run("NoValidate returns invalid values", func(t *testing.T) {
+		cfg, origins, err := LoadLayered(LoadOptions{Dir: dir, Environ: []string{"GZFLOW_BRANCHES_DEVELOP=main"}, NoValidate: true})
+		if err != nil || cfg.Branches.Develop != "main" || origins["branches.develop"].Layer != LayerEnv {
+			t.Errorf("LoadLayered(NoValidate) = %+v, %v", cfg, err)
+		}
+	})
+
+	t.Run("missing --config file", func(t *testing.T) {
+		_, _, err := LoadLayered(LoadOptions{ConfigFile: filepath.Join(dir, "missing.yaml")})
+		if err == nil {
+			t.Error("Expected error for missing --config file")
+		}
+	})
+}
+
+func TestValidate(t *testing.T) {
+	tests := []struct {
+		name    string
+		modify  func(c *Config)
+		wantErr bool
+	}{
+		{"defaults", func(c *Config) {}, false},
+		{"same master and develop", func(c *Config) { c.Branches.Develop = "master" }, true},
+		{"tag format without placeholder", func(c *Config) { c.Options.TagFormat = "v" }, true},
+		{"invalid mode", func(c *Config) { c.Guardian.Mode = "loose" }, true},
+		{"invalid pattern", func(c *Config) { c.Guardian.Naming.Pattern = "([" }, true},
+		{"severity override", func(c *Config) { c.Guardian.Severity.Naming = SeverityOff }, false},
+		{"invalid severity", func(c *Config) { c.Guardian.Severity.RequireUpToDate = "block" }, true},
+		{"push after finish with prevent direct push", func(c *Config) {
+			c.Guardian.Enabled, c.Guardian.Workflow.PreventDirectPush, c.Options.PushAfterFinish = true, true, true
+		}, true},
+		{"prevent direct push with guardian disabled", func(c *Config) {
+			c.Guardian.Workflow.PreventDirectPush, c.Options.PushAfterFinish = true, true
+		}, false},
+		{"prevent direct push turned off", func(c *Config) {
+			c.Guardian.Enabled, c.Guardian.Workflow.PreventDirectPush, c.Options.PushAfterFinish = true, true, true
+			c.Guardian.Severity.PreventDirectPush = SeverityOff
+		}, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cfg := Default()
+			tt.modify(cfg)
+			if err := cfg.Validate(); (err != nil) != tt.wantErr {
+				t.Errorf("Validate() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestPreflightConfig(t *testing.T) {
+	dir := t.TempDir()
+	content := `preflight:
+  fetch: false
+  disabled: [up-to-date]
+  release_finish:
+    enable: [up-to-date]
+  custom:
+    - name: tests
+      run: make test
+      operations: [release finish, hotfix finish]
+      timeout: 5m
+`
+	if err := os.WriteFile(filepath.Join(dir, LocalFileName), []byte(content), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	cfg, _, err := LoadLayered(LoadOptions{Dir: dir})
+	if err != nil {
+		t.Fatalf("LoadLayered failed: %v", err)
+	}
+	if err := cfg.Validate(); err != nil {
+		t.Fatalf("Validate failed: %v", err)
+	}
+
+	if cfg.Preflight.Fetch {
+		t.Error("preflight.fetch should be false")
+	}
+	if got := cfg.Preflight.ForOperation("release finish").Enable; len(got) != 1 || got[0] != "up-to-date" {
+		t.Errorf("release finish enable = %v", got)
+	}
+	if got := cfg.Preflight.ForOperation("feature finish").Enable; len(got) != 0 {
+		t.Errorf("feature finish enable = %v", got)
+	}
+
+	custom := cfg.Preflight.Custom[0]
+	if !custom.AppliesTo("hotfix finish") || custom.AppliesTo("feature finish") {
+		t.Errorf("AppliesTo wrong for %v", custom.Operations)
+	}
+	if custom.TimeoutDuration() != 5*time.Minute {
+		t.Errorf("TimeoutDuration = %v", custom.TimeoutDuration())
+	}
+
+	// Per-operation lists are settable keys; custom checks are file-only
+	if err := cfg.Set("preflight.feature_finish.disable", "merge-conflicts, up-to-date"); err != nil {
+		t.Errorf("Set failed: %v", err)
+	}
+	if v, _ := cfg.Get("preflight.feature_finish.disable"); v != "merge-conflicts,up-to-date" {
+		t.Errorf("Get = %q", v)
+	}
+	for _, key := range Keys() {
+		if key == "preflight.custom" {
+			t.Error("preflight.custom should not be a settable key")
+		}
+	}
+}
+
+func TestPreflightValidation(t *testing.T) {
+	tests := []struct {
+		name    string
+		custom  []CustomCheck
+		wantErr string
+	}{
+		{"missing run", []CustomCheck{{Name: "tests"}}, "needs a name and a run command"},
+		{"duplicate", []CustomCheck{{Name: "a", Run: "true"}, {Name: "a", Run: "false"}}, "duplicate check name 'a'"},
+		{"bad timeout", []CustomCheck{{Name: "a", Run: "true", Timeout: "soon"}}, "invalid timeout 'soon'"},
+		{"valid", []CustomCheck{{Name: "a", Run: "true", Timeout: "90s"}}, ""},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cfg := Default()
+			cfg.Preflight.Custom = tt.custom
+			err := cfg.Validate()
+			if tt.wantErr == "" {
+				if err != nil {
+					t.Errorf("Validate failed: %v", err)
+				}
+				return
+			}
+			if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+				t.Errorf("Validate error = %v, want %q", err, tt.wantErr)
+			}
+		})
+	}
+}
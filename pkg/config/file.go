@@ -0,0 +1,217 @@
+package config
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+)
+
+// LocalFileName is the project-level configuration file name
+const LocalFileName = ".gzflow.yaml"
+
+// GlobalPath returns the path of the global configuration file (~/.gz/gitflow)
+func GlobalPath() (string, error) {
+	homeDir, err := os.UserHomeDir()
+	if err != nil {
+		return "", fmt.Errorf("failed to locate home directory: %w", err)
+	}
+	return filepath.Join(homeDir, ".gz", "gitflow"), nil
+}
+
+// SetInFile sets a dotted key in the YAML file at path, keeping all other
+// keys and comments untouched. The file is created if it does not exist.
+func SetInFile(path, key, value string) error {
+	f, err := lookup(key)
+	if err != nil {
+		return err
+	}
+	parsed, err := parseValue(f, value)
+	if err != nil {
+		return err
+	}
+
+	doc, err := readDocument(path)
+	if err != nil {
+		return err
+	}
+	if err := setNode(doc, key, parsed); err != nil {
+		return err
+	}
+
+	return writeDocument(path, doc)
+}
+
+// SaveKeys writes only the given keys of c into the YAML file at path,
+// leaving any other keys in the file untouched.
+func (c *Config) SaveKeys(path string, keys ...string) error {
+	doc, err := readDocument(path)
+	if err != nil {
+		return err
+	}
+
+	for _, key := range keys {
+		f, err := lookup(key)
+		if err != nil {
+			return err
+		}
+		value := reflect.ValueOf(c).Elem().FieldByIndex(f.index).Interface()
+		if err := setNode(doc, key, value); err != nil {
+			return err
+		}
+	}
+
+	return writeDocument(path, doc)
+}
+
+// UnsetInFile removes a dotted key from the YAML file at path
+func UnsetInFile(path, key string) error {
+	if _, err := lookup(key); err != nil {
+		return err
+	}
+
+	doc, err := readDocument(path)
+	if err != nil {
+		return err
+	}
+	if !removePath(doc.Content[0], strings.Split(key, ".")) {
+		return fmt.Errorf("key '%s' is not set in %s", key, path)
+	}
+
+	return writeDocument(path, doc)
+}
+
+// setNode encodes value at the dotted key of a document
+func setNode(doc *yaml.Node, key string, value any) error {
+	var valueNode yaml.Node
+	if err := valueNode.Encode(value); err != nil {
+		return fmt.Errorf("failed to encode value for %s: %w", key, err)
+	}
+	*ensurePath(doc.Content[0], strings.Split(key, ".")) = valueNode
+	return nil
+}
+
+// readDocument parses a YAML file into a node tree, returning an empty
+// mapping document if the file does not exist or is empty.
+func readDocument(path string) (*yaml.Node, error) {
+	doc := &yaml.Node{}
+	data, err := os.ReadFile(path)
+	if err != nil && !errors.Is(err, os.ErrNotExist) {
+		return nil, fmt.Errorf("failed to read config file: %w", err)
+	}
+	if len(data) > 0 {
+		if err := yaml.Unmarshal(data, doc); err != nil {
+			return nil, fmt.Errorf("failed to parse config file: %w", err)
+		}
+	}
+
+	if doc.Kind == 0 {
+		doc.Kind = yaml.DocumentNode
+		doc.Content = []*yaml.Node{{Kind: yaml.MappingNode, Tag: "!!map"}}
+	}
+	if doc.Kind != yaml.DocumentNode || len(doc.Content) == 0 || doc.Content[0].Kind != yaml.MappingNode {
+		return nil, fmt.Errorf("config file %s is not a YAML mapping", path)
+	}
+	return doc, nil
+}
+
+// writeDocument validates the document as a Config and writes it to path
+func writeDocument(path string, doc *yaml.Node) error {
+	cfg := Default()
+	if err := doc.Decode(cfg); err != nil {
+		return fmt.Errorf("invalid configuration: %w", err)
+	}
+	if err := cfg.Validate(); err != nil {
+		return fmt.Errorf("invalid configuration: %w", err)
+	}
+
+	data, err := yaml.Marshal(doc)
+	if err != nil {
+		return fmt.Errorf("failed to marshal config: %w", err)
+	}
+
+	if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+		return fmt.Errorf("failed to create config directory: %w", err)
+	}
+	if err := os.WriteFile(path, data, 0o644); err != nil {
+		return fmt.Errorf("failed to write config file: %w", err)
+	}
+	return nil
+}
+
+// ensurePath walks (and creates) nested mappings and returns the value node for the last segment
+func ensurePath(mapping *yaml.Node, segments []string) *yaml.Node {
+	for i, seg := range segments {
+		last := i == len(segments)-1
+
+		var value *yaml.Node
+		for j := 0; j+1 < len(mapping.Content); j += 2 {
+			if mapping.Content[j].Value == seg {
+				value = mapping.Content[j+1]
+				break
+			}
+		}
+		if value == nil {
+			value = &yaml.Node{Kind: yaml.MappingNode, Tag: "!!map"}
+			mapping.Content = append(mapping.Content,
+				&yaml.Node{Kind: yaml.ScalarNode, Tag: "!!str", Value: seg}, value)
+		}
+		if last {
+			return value
+		}
+		if value.Kind != yaml.MappingNode {
+			*value = yaml.Node{Kind: yaml.MappingNode, Tag: "!!map"}
+		}
+		mapping = value
+	}
+	return mapping
+}
+
+// removePath deletes the key at segments and prunes mappings left empty.
+// It returns false if the key was not present.
+func removePath(mapping *yaml.Node, segments []string) bool {
+	for j := 0; j+1 < len(mapping.Content); j += 2 {
+		if mapping.Content[j].Value != segments[0] {
+			continue
+		}
+		value := mapping.Content[j+1]
+		if len(segments) > 1 {
+			if value.Kind != yaml.MappingNode || !removePath(value, segments[1:]) {
+				return false
+			}
+			if len(value.Content) > 0 {
+				return true
+			}
+		}
+		mapping.Content = append(mapping.Content[:j], mapping.Content[j+2:]...)
+		return true
+	}
+	return false
+}
+
+// keysInFile returns the set of dotted leaf keys present in a YAML file
+func keysInFile(path string) (map[string]bool, error) {
+	doc, err := readDocument(path)
+	if err != nil {
+		return nil, err
+	}
+
+	set := map[string]bool{}
+	var walk func(node *yaml.Node, prefix string)
+	walk = func(node *yaml.Node, prefix string) {
+		for j := 0; j+1 < len(node.Content); j += 2 {
+			key := prefix + node.Content[j].Value
+			if value := node.Content[j+1]; value.Kind == yaml.MappingNode {
+				walk(value, key+".")
+			} else {
+				set[key] = true
+			}
+		}
+	}
+	walk(doc.Content[0], "")
+	return set, nil
+}
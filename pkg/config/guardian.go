@@ -11,6 +11,33 @@ type GuardianConfig struct {
 	Mode     string        `yaml:"mode"` // "strict" or "permissive"
 	Naming   NamingRule    `yaml:"naming"`
 	Workflow WorkflowRules `yaml:"workflow"`
+	Severity RuleSeverity  `yaml:"severity"`
+}
+
+// Guardian rules whose severity can be overridden
+const (
+	RuleNaming               = "naming"
+	RuleRequireCleanTree     = "require_clean_tree"
+	RuleRequireUpToDate      = "require_up_to_date"
+	RulePreventDirectPush    = "prevent_direct_push"
+	RuleRequireLinearHistory = "require_linear_history"
+)
+
+// Severities of a rule violation
+const (
+	SeverityError = "error" // block the operation
+	SeverityWarn  = "warn"  // report and log the violation, then proceed
+	SeverityOff   = "off"   // do not check the rule
+)
+
+// RuleSeverity overrides the severity of individual rules. Empty values follow
+// the mode: error in strict mode, warn in permissive mode.
+type RuleSeverity struct {
+	Naming               string `yaml:"naming"`
+	RequireCleanTree     string `yaml:"require_clean_tree"`
+	RequireUpToDate      string `yaml:"require_up_to_date"`
+	PreventDirectPush    string `yaml:"prevent_direct_push"`
+	RequireLinearHistory string `yaml:"require_linear_history"`
 }
 
 // NamingRule defines naming constraints for branches
@@ -80,6 +107,55 @@ func (nr *NamingRule) Validate(name string) error {
 	return nil
 }
 
+// SeverityOf returns the effective severity of a rule
+func (gc *GuardianConfig) SeverityOf(rule string) string {
+	var severity string
+	switch rule {
+	case RuleNaming:
+		severity = gc.Severity.Naming
+	case RuleRequireCleanTree:
+		severity = gc.Severity.RequireCleanTree
+	case RuleRequireUpToDate:
+		severity = gc.Severity.RequireUpToDate
+	case RulePreventDirectPush:
+		severity = gc.Severity.PreventDirectPush
+	case RuleRequireLinearHistory:
+		severity = gc.Severity.RequireLinearHistory
+	}
+	if severity != "" {
+		return severity
+	}
+	if gc.Mode == "permissive" {
+		return SeverityWarn
+	}
+	return SeverityError
+}
+
+// validate checks the mode and the severity overrides
+func (gc *GuardianConfig) validate() error {
+	if gc.Mode != "strict" && gc.Mode != "permissive" {
+		return fmt.Errorf("guardian.mode must be 'strict' or 'permissive' (got '%s')", gc.Mode)
+	}
+	overrides := []struct{ rule, severity string }{
+		{RuleNaming, gc.Severity.Naming},
+		{RuleRequireCleanTree, gc.Severity.RequireCleanTree},
+		{RuleRequireUpToDate, gc.Severity.RequireUpToDate},
+		{RulePreventDirectPush, gc.Severity.PreventDirectPush},
+		{RuleRequireLinearHistory, gc.Severity.RequireLinearHistory},
+	}
+	for _, o := range overrides {
+		switch o.severity {
+		case "", SeverityError, SeverityWarn, SeverityOff:
+		default:
+			return fmt.Errorf("guardian.severity.%s must be 'error', 'warn' or 'off' (got '%s')", o.rule, o.severity)
+		}
+	}
+	if _, err := regexp.Compile(gc.Naming.Pattern); err != nil {
+		return fmt.Errorf("guardian.naming.pattern is not a valid regex: %w", err)
+	}
+	return nil
+}
+
 // ValidateBranchName validates a full branch name (with prefix) against Guardian rules
 func (gc *GuardianConfig) ValidateBranchName(fullName string, prefix string) error {
 	if !gc.Enabled {
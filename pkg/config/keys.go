@@ -0,0 +1,132 @@
+package config
+
+import (
+	"fmt"
+	"reflect"
+	"strconv"
+	"strings"
+)
+
+// field describes a settable leaf of Config addressed by a dotted key
+type field struct {
+	key   string
+	index []int
+	typ   reflect.Type
+}
+
+// fields walks Config and returns every leaf field in declaration order
+func fields() []field {
+	var out []field
+	var walk func(t reflect.Type, prefix string, index []int)
+	walk = func(t reflect.Type, prefix string, index []int) {
+		for i := 0; i < t.NumField(); i++ {
+			f := t.Field(i)
+			name := strings.Split(f.Tag.Get("yaml"), ",")[0]
+			if !f.IsExported() || name == "" || name == "-" {
+				continue
+			}
+			key := prefix + name
+			idx := append(append([]int{}, index...), i)
+			if f.Type.Kind() == reflect.Struct {
+				walk(f.Type, key+".", idx)
+				continue
+			}
+			// Lists of structs (preflight.custom) can only be set in files
+			if f.Type.Kind() == reflect.Slice && f.Type.Elem().Kind() == reflect.Struct {
+				continue
+			}
+			out = append(out, field{key: key, index: idx, typ: f.Type})
+		}
+	}
+	walk(reflect.TypeOf(Config{}), "", nil)
+	return out
+}
+
+// lookup finds the field for a dotted key
+func lookup(key string) (field, error) {
+	for _, f := range fields() {
+		if f.key == key {
+			return f, nil
+		}
+	}
+	return field{}, fmt.Errorf("unknown configuration key '%s'", key)
+}
+
+// Keys returns all dotted configuration keys in declaration order
+func Keys() []string {
+	fs := fields()
+	keys := make([]string, len(fs))
+	for i, f := range fs {
+		keys[i] = f.key
+	}
+	return keys
+}
+
+// Get returns the value of a dotted key formatted as a string
+func (c *Config) Get(key string) (string, error) {
+	f, err := lookup(key)
+	if err != nil {
+		return "", err
+	}
+	return formatValue(reflect.ValueOf(c).Elem().FieldByIndex(f.index)), nil
+}
+
+// Set parses value according to the type of key and assigns it
+func (c *Config) Set(key, value string) error {
+	f, err := lookup(key)
+	if err != nil {
+		return err
+	}
+	parsed, err := parseValue(f, value)
+	if err != nil {
+		return err
+	}
+	reflect.ValueOf(c).Elem().FieldByIndex(f.index).Set(reflect.ValueOf(parsed))
+
+	// Invalidate the cached naming regex in case the pattern changed
+	c.Guardian.Naming.compiled = nil
+	return nil
+}
+
+// parseValue converts a string into the Go type of the field
+func parseValue(f field, value string) (any, error) {
+	switch f.typ.Kind() {
+	case reflect.String:
+		return value, nil
+	case reflect.Bool:
+		b, err := strconv.ParseBool(value)
+		if err != nil {
+			return nil, fmt.Errorf("invalid value '%s' for %s: expected true or false", value, f.key)
+		}
+		return b, nil
+	case reflect.Int:
+		n, err := strconv.Atoi(value)
+		if err != nil {
+			return nil, fmt.Errorf("invalid value '%s' for %s: expected an integer", value, f.key)
+		}
+		return n, nil
+	case reflect.Slice:
+		if f.typ.Elem().Kind() == reflect.String {
+			items := []string{}
+			for _, item := range strings.Split(value, ",") {
+				if item = strings.TrimSpace(item); item != "" {
+					items = append(items, item)
+				}
+			}
+			return items, nil
+		}
+	}
+	return nil, fmt.Errorf("unsupported type %s for %s", f.typ, f.key)
+}
+
+// formatValue renders a field value the way it is accepted by Set
+func formatValue(v reflect.Value) string {
+	if v.Kind() == reflect.Slice {
+		items := make([]string, v.Len())
+		for i := range items {
+			items[i] = fmt.Sprint(v.Index(i).Interface())
+		}
+		return strings.Join(items, ",")
+	}
+	return fmt.Sprint(v.Interface())
+}
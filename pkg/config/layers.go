@@ -0,0 +1,171 @@
+package config
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+)
+
+// EnvPrefix is the prefix of environment variables that override config keys.
+// The key options.push_after_finish maps to GZFLOW_OPTIONS_PUSH_AFTER_FINISH.
+const EnvPrefix = "GZFLOW_"
+
+// Layer names a configuration source, in increasing order of precedence
+type Layer string
+
+const (
+	LayerDefault Layer = "default"
+	LayerGlobal  Layer = "global"
+	LayerProject Layer = "project"
+	LayerEnv     Layer = "env"
+	LayerFlag    Layer = "flag"
+)
+
+// Origin identifies the layer, and the file or variable within it, that supplied a value
+type Origin struct {
+	Layer  Layer
+	Source string // file path or environment variable; empty for defaults
+}
+
+// String renders the origin as "layer:source"
+func (o Origin) String() string {
+	if o.Source == "" {
+		return string(o.Layer)
+	}
+	return string(o.Layer) + ":" + o.Source
+}
+
+// Origins maps each dotted key to the origin of its effective value
+type Origins map[string]Origin
+
+// LoadOptions selects the layers merged by LoadLayered.
+// The zero value merges only the defaults and the global config.
+type LoadOptions struct {
+	Dir        string   // directory holding .gzflow.yaml; empty skips the project layer
+	Environ    []string // environment as KEY=VALUE pairs; nil skips the env layer
+	ConfigFile string   // explicit config file (--config), applied last; must exist
+	NoValidate bool     // return the merged values even if they fail Validate
+}
+
+// InvalidError reports a merged configuration that fails Validate. Its
+// message names the layer each offending non-default value came from.
+type InvalidError struct {
+	Err     error
+	Origins Origins
+}
+
+func (e *InvalidError) Error() string {
+	msg := e.Err.Error()
+	var from []string
+	for _, key := range Keys() {
+		if origin := e.Origins[key]; origin.Layer != LayerDefault && strings.Contains(msg, key) {
+			from = append(from, key+" from "+origin.String())
+		}
+	}
+	if len(from) > 0 {
+		msg += " (" + strings.Join(from, ", ") + ")"
+	}
+	return "invalid configuration: " + msg
+}
+
+func (e *InvalidError) Unwrap() error {
+	return e.Err
+}
+
+// EnvVar returns the environment variable that overrides a dotted key
+func EnvVar(key string) string {
+	return EnvPrefix + strings.ToUpper(strings.ReplaceAll(key, ".", "_"))
+}
+
+// LoadLayered merges, field by field: defaults, global config, project config,
+// environment variables and the explicit config file. It reports the origin
+// of every effective value. A merged configuration that fails Validate
+// returns an *InvalidError, unless opts.NoValidate is set.
+func LoadLayered(opts LoadOptions) (*Config, Origins, error) {
+	cfg := Default()
+	origins := Origins{}
+	for _, key := range Keys() {
+		origins[key] = Origin{Layer: LayerDefault}
+	}
+
+	// 1. Global config
+	if globalPath, err := GlobalPath(); err == nil {
+		if err := applyFile(cfg, origins, globalPath, LayerGlobal, false); err != nil {
+			return nil, nil, err
+		}
+	}
+
+	// 2. Project config
+	if opts.Dir != "" {
+		localPath := filepath.Join(opts.Dir, LocalFileName)
+		if err := applyFile(cfg, origins, localPath, LayerProject, false); err != nil {
+			return nil, nil, err
+		}
+	}
+
+	// 3. Environment variables
+	if opts.Environ != nil {
+		env := map[string]string{}
+		for _, kv := range opts.Environ {
+			if name, value, ok := strings.Cut(kv, "="); ok && strings.HasPrefix(name, EnvPrefix) {
+				env[name] = value
+			}
+		}
+		for _, key := range Keys() {
+			name := EnvVar(key)
+			value, ok := env[name]
+			if !ok {
+				continue
+			}
+			if err := cfg.Set(key, value); err != nil {
+				return nil, nil, fmt.Errorf("%s: %w", name, err)
+			}
+			origins[key] = Origin{Layer: LayerEnv, Source: name}
+		}
+	}
+
+	// 4. Explicit config file
+	if opts.ConfigFile != "" {
+		if err := applyFile(cfg, origins, opts.ConfigFile, LayerFlag, true); err != nil {
+			return nil, nil, err
+		}
+	}
+
+	// 5. Validate the merged result; values are only checked together
+	if err := cfg.Validate(); err != nil && !opts.NoValidate {
+		return nil, nil, &InvalidError{Err: err, Origins: origins}
+	}
+
+	return cfg, origins, nil
+}
+
+// applyFile decodes a YAML file on top of cfg; only keys present in the file
+// change, so layers merge field by field. Missing files are skipped unless required.
+func applyFile(cfg *Config, origins Origins, path string, layer Layer, required bool) error {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		if os.IsNotExist(err) && !required {
+			return nil
+		}
+		return fmt.Errorf("failed to read config file: %w", err)
+	}
+
+	if err := yaml.Unmarshal(data, cfg); err != nil {
+		return fmt.Errorf("failed to parse config file %s: %w", path, err)
+	}
+	cfg.Guardian.Naming.compiled = nil
+
+	set, err := keysInFile(path)
+	if err != nil {
+		return err
+	}
+	for key := range origins {
+		if set[key] {
+			origins[key] = Origin{Layer: layer, Source: path}
+		}
+	}
+	return nil
+}
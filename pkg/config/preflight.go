@@ -0,0 +1,87 @@
+package config
+
+import (
+	"fmt"
+	"time"
+)
+
+// PreflightConfig selects and tunes the pre-flight checks of each operation.
+// Checks are named after a built-in check (e.g. clean-tree, merge-conflicts)
+// or a custom check.
+type PreflightConfig struct {
+	Fetch         bool            `yaml:"fetch"`    // fetch the remote before the up-to-date check
+	Disabled      []string        `yaml:"disabled"` // checks skipped by every operation
+	FeatureFinish OperationChecks `yaml:"feature_finish"`
+	ReleaseFinish OperationChecks `yaml:"release_finish"`
+	HotfixFinish  OperationChecks `yaml:"hotfix_finish"`
+	Custom        []CustomCheck   `yaml:"custom"` // file only; not settable with 'config'
+}
+
+// OperationChecks overrides the enabled checks for one operation
+type OperationChecks struct {
+	Enable  []string `yaml:"enable"`  // run even if disabled globally or limited to other operations
+	Disable []string `yaml:"disable"` // skip for this operation
+}
+
+// CustomCheck is a pre-flight check that runs a shell command and passes if
+// it exits with status 0
+type CustomCheck struct {
+	Name       string   `yaml:"name"`
+	Run        string   `yaml:"run"`
+	Hint       string   `yaml:"hint,omitempty"`
+	Operations []string `yaml:"operations,omitempty"` // e.g. "release finish"; empty means all
+	Timeout    string   `yaml:"timeout,omitempty"`    // Go duration such as "5m"; empty means the default
+}
+
+// ForOperation returns the overrides of an operation ("feature finish",
+// "release finish" or "hotfix finish")
+func (pc *PreflightConfig) ForOperation(operation string) OperationChecks {
+	switch operation {
+	case "feature finish":
+		return pc.FeatureFinish
+	case "release finish":
+		return pc.ReleaseFinish
+	case "hotfix finish":
+		return pc.HotfixFinish
+	}
+	return OperationChecks{}
+}
+
+// AppliesTo reports whether the custom check runs for an operation by default
+func (cc *CustomCheck) AppliesTo(operation string) bool {
+	if len(cc.Operations) == 0 {
+		return true
+	}
+	for _, op := range cc.Operations {
+		if op == operation {
+			return true
+		}
+	}
+	return false
+}
+
+// TimeoutDuration returns the parsed timeout, or 0 if none is set
+func (cc *CustomCheck) TimeoutDuration() time.Duration {
+	d, _ := time.ParseDuration(cc.Timeout)
+	return d
+}
+
+// validate checks that custom checks are complete and uniquely named
+func (pc *PreflightConfig) validate() error {
+	seen := map[string]bool{}
+	for i, cc := range pc.Custom {
+		if cc.Name == "" || cc.Run == "" {
+			return fmt.Errorf("preflight.custom[%d] needs a name and a run command", i)
+		}
+		if seen[cc.Name] {
+			return fmt.Errorf("preflight.custom: duplicate check name '%s'", cc.Name)
+		}
+		seen[cc.Name] = true
+		if cc.Timeout != "" {
+			if d, err := time.ParseDuration(cc.Timeout); err != nil || d <= 0 {
+				return fmt.Errorf("preflight.custom '%s': invalid timeout '%s' (use e.g. 90s or 5m)", cc.Name, cc.Timeout)
+			}
+		}
+	}
+	return nil
+}
@@ -0,0 +1,123 @@
+// tests/integration/audit_test.go
+
+package integration
+
+import (
+	"encoding/json"
+	"os"
+	"os/exec"
+	"strings"
+	"testing"
+	"time"
+)
+
+// commitAged makes an empty commit by author, dated days ago
+func commitAged(t *testing.T, dir string, days int, author, message string) {
+	t.Helper()
+	date := time.Now().AddDate(0, 0, -days).Format(time.RFC3339)
+	cmd := exec.Command("git", "commit", "--allow-empty", "-m", message)
+	cmd.Dir = dir
+	cmd.Env = append(os.Environ(),
+		"GIT_AUTHOR_NAME="+author, "GIT_COMMITTER_NAME="+author,
+		"GIT_AUTHOR_DATE="+date, "GIT_COMMITTER_DATE="+date)
+	if out, err := cmd.CombinedOutput(); err != nil {
+		t.Fatalf("git commit failed: %v\n%s", err, out)
+	}
+}
+
+// setupAuditRepo creates one healthy, one stale, one misnamed and one merged feature
+func setupAuditRepo(t *testing.T) string {
+	t.Helper()
+	dir := setupTestRepo(t)
+
+	run(t, dir, "git", "checkout", "-b", "feature/healthy")
+	commitAged(t, dir, 1, "Ann", "Recent work")
+	run(t, dir, "git", "checkout", "-b", "feature/old-login", "develop")
+	commitAged(t, dir, 45, "Kim", "Old work")
+	run(t, dir, "git", "checkout", "-b", "feature/Login_Page", "develop")
+	commitAged(t, dir, 2, "Lee", "Misnamed work")
+	run(t, dir, "git", "checkout", "-b", "feature/done", "develop")
+	commitAged(t, dir, 3, "Park", "Done work")
+	run(t, dir, "git", "checkout", "develop")
+	run(t, dir, "git", "merge", "--no-ff", "feature/done", "-m", "Merge feature/done")
+	return dir
+}
+
+func TestAudit(t *testing.T) {
+	binary := buildBinary(t)
+	dir := setupAuditRepo(t)
+
+	out, err := runFlow(t, binary, dir, "audit")
+	if err != nil {
+		t.Fatalf("audit failed: %v\nOutput: %s", err, out)
+	}
+	for _, want := range []string{
+		"⚠️  Stale branches (>30 days):",
+		"feature/old-login (45 days, Kim)",
+		"feature/Login_Page → should be: feature/login-page (Lee)",
+		"feature/done → merged to develop (Park)",
+		"📊 Summary: 1 stale, 1 violation, 1 merged, 1 healthy",
+	} {
+		if !strings.Contains(out, want) {
+			t.Errorf("Output missing %q:\n%s", want, out)
+		}
+	}
+
+	out, err = runFlow(t, binary, dir, "audit", "feature", "--stale-days", "60")
+	if err != nil || !strings.Contains(out, "0 stale") {
+		t.Errorf("--stale-days 60 should find no stale branch: %v\n%s", err, out)
+	}
+
+	// A branch without commits of its own is not merged after develop moves
+	// on; one merged by fast-forward is
+	run(t, dir, "git", "branch", "feature/fresh", "develop")
+	run(t, dir, "git", "checkout", "-b", "feature/ff", "develop")
+	commitAged(t, dir, 1, "Ann", "FF work")
+	run(t, dir, "git", "checkout", "develop")
+	run(t, dir, "git", "merge", "--ff-only", "feature/ff")
+	out, err = runFlow(t, binary, dir, "audit")
+	if err != nil || strings.Contains(out, "feature/fresh → merged") || !strings.Contains(out, "feature/ff → merged to develop (Ann)") ||
+		!strings.Contains(out, "2 merged, 2 healthy") {
+		t.Errorf("feature/fresh should be healthy and feature/ff merged: %v\n%s", err, out)
+	}
+}
+
+func TestAuditJSONThreshold(t *testing.T) {
+	binary := buildBinary(t)
+	dir := setupAuditRepo(t)
+
+	out, code := runFlowStructured(t, binary, dir, "audit", "-o", "json", "--max-issues", "2")
+	if code != 9 {
+		t.Fatalf("exit %d, want 9\n%s", code, out)
+	}
+	var report struct {
+		Stale []struct {
+			Name   string `json:"name"`
+			Author string `json:"author"`
+		} `json:"stale"`
+		Naming []struct {
+			Branch    string `json:"branch"`
+			Suggested string `json:"suggested"`
+		} `json:"naming"`
+		Merged   []struct{ Name string } `json:"merged"`
+		Healthy  int                     `json:"healthy"`
+		Issues   int                     `json:"issues"`
+		Error    string                  `json:"error"`
+		ExitCode int                     `json:"exit_code"`
+	}
+	if err := json.Unmarshal(out, &report); err != nil {
+		t.Fatalf("invalid JSON: %v\n%s", err, out)
+	}
+	if len(report.Stale) != 1 || report.Stale[0].Author != "Kim" ||
+		len(report.Naming) != 1 || report.Naming[0].Suggested != "feature/login-page" ||
+		len(report.Merged) != 1 || report.Healthy != 1 || report.Issues != 3 {
+		t.Errorf("Unexpected report: %+v", report)
+	}
+	if report.ExitCode != 9 || !strings.Contains(report.Error, "3 issue(s), more than the allowed 2") {
+		t.Errorf("Expected the threshold error in the report: %+v", report)
+	}
+
+	if out, code := runFlowStructured(t, binary, dir, "audit", "-o", "json", "--max-issues", "3"); code != 0 {
+		t.Errorf("exit %d at the threshold, want 0\n%s", code, out)
+	}
+}
@@ -0,0 +1,118 @@
+// tests/integration/checkout_test.go
+
+package integration
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestFeatureCheckoutFuzzy(t *testing.T) {
+	binary := buildBinary(t)
+	dir := setupTestRepo(t)
+
+	for _, name := range []string{"user-authentication-v2-oauth", "auth", "payment-auth", "dashboard"} {
+		run(t, dir, "git", "branch", "feature/"+name, "develop")
+	}
+
+	cases := []struct {
+		query  string
+		branch string // empty: no single match
+		output string
+	}{
+		{"auth", "feature/auth", ""},                           // exact name wins
+		{"OAUTH", "feature/user-authentication-v2-oauth", ""},  // substring, any case
+		{"feature/dash", "feature/dashboard", ""},              // prefix is optional
+		{"uav2", "feature/user-authentication-v2-oauth", ""},   // letters in order
+		{"au", "", "🔍 'au' matches 3 feature branches:"},       // ambiguous
+		{"billing", "", "no feature branch matches 'billing'"}, // no match
+	}
+	for _, tc := range cases {
+		run(t, dir, "git", "checkout", "develop")
+		out, err := runFlow(t, binary, dir, "feature", "checkout", tc.query)
+		current := strings.TrimSpace(gitCommand(t, dir, "branch", "--show-current"))
+		if tc.branch == "" {
+			if err == nil || current != "develop" || !strings.Contains(out, tc.output) {
+				t.Errorf("checkout %s: expected %q and no switch, got %v on %s:\n%s", tc.query, tc.output, err, current, out)
+			}
+			continue
+		}
+		if err != nil || current != tc.branch {
+			t.Errorf("checkout %s: expected %s, got %v on %s:\n%s", tc.query, tc.branch, err, current, out)
+		}
+	}
+
+	out, _ := runFlow(t, binary, dir, "feature", "switch", "au")
+	for _, candidate := range []string{"feature/auth (", "feature/payment-auth (", "feature/user-authentication-v2-oauth ("} {
+		if !strings.Contains(out, candidate) {
+			t.Errorf("Expected candidate %q:\n%s", candidate, out)
+		}
+	}
+}
+
+func TestFeatureCheckoutAutostash(t *testing.T) {
+	binary := buildBinary(t)
+	dir := setupTestRepo(t)
+	run(t, dir, "git", "branch", "feature/login", "develop")
+	run(t, dir, "git", "branch", "feature/payment", "develop")
+	run(t, dir, "git", "checkout", "feature/login")
+
+	if err := os.WriteFile(filepath.Join(dir, "README.md"), []byte("login work\n"), testFileMode); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(filepath.Join(dir, "login.go"), []byte("new file\n"), testFileMode); err != nil {
+		t.Fatal(err)
+	}
+
+	// The dry run shows the stash and changes nothing
+	out, err := runFlow(t, binary, dir, "--dry-run", "feature", "checkout", "pay", "--autostash")
+	if err != nil || !strings.Contains(out, `git stash push --include-untracked -m "gz-flow autostash: feature/login"`) {
+		t.Fatalf("dry run failed: %v\n%s", err, out)
+	}
+
+	out, err = runFlow(t, binary, dir, "feature", "checkout", "pay", "--autostash")
+	if err != nil {
+		t.Fatalf("checkout failed: %v\nOutput: %s", err, out)
+	}
+	if !strings.Contains(out, "📦 Stashed uncommitted work of 'feature/login'") {
+		t.Errorf("Expected a stash message:\n%s", out)
+	}
+	if status := gitCommand(t, dir, "status", "--porcelain"); strings.TrimSpace(status) != "" {
+		t.Errorf("feature/payment should be clean, got: %s", status)
+	}
+
+	// Other stashes on the way back do not get in the way
+	if err := os.WriteFile(filepath.Join(dir, "payment.go"), []byte("payment\n"), testFileMode); err != nil {
+		t.Fatal(err)
+	}
+	out, err = runFlow(t, binary, dir, "feature", "checkout", "login", "--autostash")
+	if err != nil {
+		t.Fatalf("checkout back failed: %v\nOutput: %s", err, out)
+	}
+	if !strings.Contains(out, "📦 Restored work stashed on 'feature/login'") {
+		t.Errorf("Expected the work to be restored:\n%s", out)
+	}
+	if data, _ := os.ReadFile(filepath.Join(dir, "README.md")); string(data) != "login work\n" {
+		t.Errorf("README.md = %q", data)
+	}
+	if _, err := os.Stat(filepath.Join(dir, "login.go")); err != nil {
+		t.Errorf("untracked login.go not restored: %v", err)
+	}
+	if _, err := os.Stat(filepath.Join(dir, "payment.go")); err == nil {
+		t.Error("payment.go should stay stashed for feature/payment")
+	}
+	if stash := gitCommand(t, dir, "stash", "list"); !strings.Contains(stash, "gz-flow autostash: feature/payment") || strings.Contains(stash, "feature/login") {
+		t.Errorf("Unexpected stash list: %s", stash)
+	}
+
+	// Without --autostash, uncommitted work moves along and the stash stays put
+	out, err = runFlow(t, binary, dir, "feature", "checkout", "payment")
+	if err != nil || !strings.Contains(out, "Work stashed on 'feature/payment' is not restored while you have uncommitted changes") {
+		t.Errorf("Expected the payment stash to stay put: %v\n%s", err, out)
+	}
+	if _, err := os.Stat(filepath.Join(dir, "payment.go")); err == nil {
+		t.Error("payment.go should not be restored over uncommitted work")
+	}
+}
@@ -0,0 +1,116 @@
+// tests/integration/cleanup_test.go
+
+package integration
+
+import (
+	"strings"
+	"testing"
+)
+
+// setupCleanupRepo creates merged (with and without a merge commit), unmerged,
+// stale and fresh flow branches
+// and publishes them to origin
+func setupCleanupRepo(t *testing.T) (string, string) {
+	t.Helper()
+	dir := setupTestRepo(t)
+	remote := addBareRemote(t, dir, "origin")
+
+	// feature/ff is merged by fast-forward, so develop's history contains its tip
+	run(t, dir, "git", "checkout", "-b", "feature/ff", "develop")
+	commitAged(t, dir, 40, "Kim", "Work on feature/ff")
+	run(t, dir, "git", "checkout", "develop")
+	run(t, dir, "git", "merge", "--ff-only", "feature/ff")
+	for _, name := range []string{"feature/done", "feature/current"} {
+		run(t, dir, "git", "checkout", "-b", name, "develop")
+		commitAged(t, dir, 40, "Kim", "Work on "+name)
+		run(t, dir, "git", "checkout", "develop")
+		run(t, dir, "git", "merge", "--no-ff", name, "-m", "Merge "+name)
+	}
+	run(t, dir, "git", "checkout", "-b", "hotfix/1.0.1", "master")
+	commitAged(t, dir, 1, "Lee", "Recent fix")
+	run(t, dir, "git", "checkout", "master")
+	run(t, dir, "git", "merge", "--no-ff", "hotfix/1.0.1", "-m", "Merge hotfix/1.0.1")
+	run(t, dir, "git", "checkout", "-b", "feature/abandoned", "develop")
+	commitAged(t, dir, 60, "Park", "Abandoned work")
+	run(t, dir, "git", "branch", "feature/fresh", "develop")
+	// develop moves on, so feature/fresh is reachable from it without being merged
+	run(t, dir, "git", "checkout", "develop")
+	commitFile(t, dir, "later.txt", "later\n", "Later work on develop")
+	run(t, dir, "git", "push", "origin", "master", "develop", "feature/done", "feature/abandoned")
+	run(t, dir, "git", "checkout", "feature/current")
+	return dir, remote
+}
+
+func TestCleanup(t *testing.T) {
+	binary := buildBinary(t)
+	dir, remote := setupCleanupRepo(t)
+
+	// The preview changes nothing
+	out, err := runFlow(t, binary, dir, "--dry-run", "cleanup", "--stale-days", "30")
+	if err != nil {
+		t.Fatalf("cleanup --dry-run failed: %v\nOutput: %s", err, out)
+	}
+	for _, want := range []string{
+		"🧹 Cleanup Preview",
+		"Will delete (already merged):\n  feature/done  → merged to develop\n  feature/ff    → merged to develop",
+		"feature/current  → current branch",
+		"hotfix/1.0.1     → merged to master, active 1 day ago",
+		"feature/fresh    → no commits yet",
+		"Will warn (stale, not merged):\n  feature/abandoned  → 60 days, no activity",
+	} {
+		if !strings.Contains(out, want) {
+			t.Errorf("Preview missing %q:\n%s", want, out)
+		}
+	}
+	if !branchExists(t, dir, "feature/done") {
+		t.Fatal("dry run should not delete branches")
+	}
+
+	// Nothing is deleted without a confirmation
+	out, _ = runFlow(t, binary, dir, "cleanup")
+	if !strings.Contains(out, "Delete 2 branches?") && !strings.Contains(out, "Pass --yes") {
+		t.Errorf("cleanup should ask for confirmation:\n%s", out)
+	}
+	if !branchExists(t, dir, "feature/done") {
+		t.Fatal("cleanup should not delete branches without confirmation")
+	}
+
+	out, err = runFlow(t, binary, dir, "cleanup", "--yes", "--include-remote")
+	if err != nil {
+		t.Fatalf("cleanup failed: %v\nOutput: %s", err, out)
+	}
+	for _, branch := range []string{"feature/done", "feature/ff"} {
+		if branchExists(t, dir, branch) {
+			t.Errorf("%s should be deleted", branch)
+		}
+	}
+	for _, branch := range []string{"feature/current", "feature/abandoned", "feature/fresh", "hotfix/1.0.1"} {
+		if !branchExists(t, dir, branch) {
+			t.Errorf("%s should be kept", branch)
+		}
+	}
+	if !strings.Contains(out, "hotfix/1.0.1     → merged to master; check out master to delete it") {
+		t.Errorf("Expected git branch -d to be left a branch HEAD contains:\n%s", out)
+	}
+	if got := gitCommand(t, remote, "branch", "--list", "feature/*"); strings.Contains(got, "feature/done") || !strings.Contains(got, "feature/abandoned") {
+		t.Errorf("remote branches after cleanup: %s", got)
+	}
+	if !strings.Contains(out, "git push origin --delete feature/done") {
+		t.Errorf("Expected the remote delete:\n%s", out)
+	}
+
+	// From master the hotfix goes too; the fresh branch stays
+	run(t, dir, "git", "checkout", "master")
+	out, err = runFlow(t, binary, dir, "cleanup", "--yes")
+	if err != nil {
+		t.Fatalf("cleanup from master failed: %v\nOutput: %s", err, out)
+	}
+	if branchExists(t, dir, "hotfix/1.0.1") || !branchExists(t, dir, "feature/fresh") {
+		t.Errorf("Expected only hotfix/1.0.1 to be deleted:\n%s", out)
+	}
+}
+
+func branchExists(t *testing.T, dir, branch string) bool {
+	t.Helper()
+	return strings.TrimSpace(gitCommand(t, dir, "branch", "--list", branch)) != ""
+}
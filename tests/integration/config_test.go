@@ -0,0 +1,159 @@
+// tests/integration/config_test.go
+
+package integration
+
+import (
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestConfigGetSet(t *testing.T) {
+	binary := buildBinary(t)
+	dir := setupTestRepo(t)
+
+	if out, err := runFlow(t, binary, dir, "config", "branches.master", "main"); err != nil {
+		t.Fatalf("config set failed: %v\nOutput: %s", err, out)
+	}
+
+	out, err := runFlow(t, binary, dir, "config", "branches.master")
+	if err != nil {
+		t.Fatalf("config get failed: %v\nOutput: %s", err, out)
+	}
+	if strings.TrimSpace(out) != "main" {
+		t.Errorf("Expected 'main', got %q", out)
+	}
+
+	out, err = runFlow(t, binary, dir, "config", "options.push_after_finish", "yes")
+	if err == nil {
+		t.Errorf("Setting a string on a bool key should fail. Output: %s", out)
+	}
+
+	out, err = runFlow(t, binary, dir, "config", "branches.unknown", "x")
+	if err == nil || !strings.Contains(out, "unknown configuration key") {
+		t.Errorf("Unknown key should be rejected. Output: %s", out)
+	}
+
+	out, err = runFlow(t, binary, dir, "config", "--list", "--show-origin")
+	if err != nil {
+		t.Fatalf("config --list --show-origin failed: %v\nOutput: %s", err, out)
+	}
+	if !strings.Contains(out, "project:.gzflow.yaml\tbranches.master=main") {
+		t.Errorf("Expected local origin for branches.master:\n%s", out)
+	}
+	if !strings.Contains(out, "default\tbranches.develop=develop") {
+		t.Errorf("Expected default origin for branches.develop:\n%s", out)
+	}
+
+	if out, err := runFlow(t, binary, dir, "config", "--unset", "branches.master"); err != nil {
+		t.Fatalf("config --unset failed: %v\nOutput: %s", err, out)
+	}
+	out, _ = runFlow(t, binary, dir, "config", "branches.master")
+	if strings.TrimSpace(out) != "master" {
+		t.Errorf("Expected default 'master' after unset, got %q", out)
+	}
+}
+
+func TestConfigGlobal(t *testing.T) {
+	binary := buildBinary(t)
+	dir := setupTestRepo(t)
+	home := t.TempDir()
+
+	cmd := exec.Command(binary, "config", "--global", "options.tag_format", "release-%s")
+	cmd.Dir = dir
+	cmd.Env = append(os.Environ(), "HOME="+home)
+	if out, err := cmd.CombinedOutput(); err != nil {
+		t.Fatalf("config --global failed: %v\nOutput: %s", err, out)
+	}
+
+	data, err := os.ReadFile(filepath.Join(home, ".gz", "gitflow"))
+	if err != nil {
+		t.Fatalf("global config should be written: %v", err)
+	}
+	if !strings.Contains(string(data), "release-%s") {
+		t.Errorf("Unexpected global config:\n%s", data)
+	}
+	if _, err := os.Stat(filepath.Join(dir, ".gzflow.yaml")); !os.IsNotExist(err) {
+		t.Error("--global should not write the local config")
+	}
+}
+
+func TestConfigLayers(t *testing.T) {
+	binary := buildBinary(t)
+	dir := setupTestRepo(t)
+
+	if out, err := runFlow(t, binary, dir, "config", "branches.master", "main"); err != nil {
+		t.Fatalf("config set failed: %v\nOutput: %s", err, out)
+	}
+	ciConfig := filepath.Join(t.TempDir(), "ci.yaml")
+	if err := os.WriteFile(ciConfig, []byte("branches:\n  master: production\n"), testFileMode); err != nil {
+		t.Fatal(err)
+	}
+
+	out, err := runFlow(t, binary, dir, "--config", ciConfig, "config", "--show-origin", "branches.master")
+	if err != nil {
+		t.Fatalf("config get failed: %v\nOutput: %s", err, out)
+	}
+	if strings.TrimSpace(out) != "flag:"+ciConfig+"\tproduction" {
+		t.Errorf("--config should win over the project file, got %q", out)
+	}
+
+	cmd := exec.Command(binary, "config", "--show-origin", "branches.master")
+	cmd.Dir = dir
+	cmd.Env = append(os.Environ(), "GZFLOW_BRANCHES_MASTER=trunk")
+	envOut, err := cmd.CombinedOutput()
+	if err != nil {
+		t.Fatalf("config get failed: %v\nOutput: %s", err, envOut)
+	}
+	if strings.TrimSpace(string(envOut)) != "env:GZFLOW_BRANCHES_MASTER\ttrunk" {
+		t.Errorf("environment should win over the project file, got %q", envOut)
+	}
+}
+
+func TestConfigInvalidLayers(t *testing.T) {
+	binary := buildBinary(t)
+	dir := setupTestRepo(t)
+
+	// Every command refuses a merged configuration config set would reject
+	for _, args := range [][]string{{"status"}, {"feature", "start", "login"}} {
+		cmd := exec.Command(binary, args...)
+		cmd.Dir = dir
+		cmd.Env = append(os.Environ(), "GZFLOW_BRANCHES_DEVELOP=master")
+		out, err := cmd.CombinedOutput()
+		exitErr, ok := err.(*exec.ExitError)
+		if !ok || exitErr.ExitCode() != 4 {
+			t.Errorf("%v: expected exit 4, got %v\n%s", args, err, out)
+		}
+		if !strings.Contains(string(out), "branches.master and branches.develop must differ (both are 'master') (branches.develop from env:GZFLOW_BRANCHES_DEVELOP)") {
+			t.Errorf("%v: expected the key and origin in the error:\n%s", args, out)
+		}
+	}
+	if branchExists(t, dir, "feature/login") {
+		t.Error("feature start should not run with an invalid configuration")
+	}
+
+	// The listing still works, so the origin can be found
+	cmd := exec.Command(binary, "config", "--list", "--show-origin")
+	cmd.Dir = dir
+	cmd.Env = append(os.Environ(), "GZFLOW_BRANCHES_DEVELOP=master")
+	out, err := cmd.CombinedOutput()
+	if err != nil {
+		t.Fatalf("config --list failed: %v\n%s", err, out)
+	}
+	for _, want := range []string{
+		"⚠️  invalid configuration: branches.master and branches.develop must differ",
+		"env:GZFLOW_BRANCHES_DEVELOP\tbranches.develop=master",
+	} {
+		if !strings.Contains(string(out), want) {
+			t.Errorf("config --list missing %q:\n%s", want, out)
+		}
+	}
+
+	writeProjectConfig(t, dir, "options:\n  tag_format: latest\n")
+	status, err := runFlow(t, binary, dir, "status")
+	if err == nil || !strings.Contains(status, "options.tag_format must contain exactly one %s (got 'latest') (options.tag_format from project:") {
+		t.Errorf("Expected the project file to be named: %v\n%s", err, status)
+	}
+}
@@ -0,0 +1,81 @@
+// tests/integration/dryrun_test.go
+
+package integration
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestDryRunChangesNothing(t *testing.T) {
+	binary := buildBinary(t)
+	dir := setupTestRepo(t)
+
+	out, err := runFlow(t, binary, dir, "--dry-run", "feature", "start", "login")
+	if err != nil {
+		t.Fatalf("feature start --dry-run failed: %v\nOutput: %s", err, out)
+	}
+	if !strings.Contains(out, "1. git checkout develop") || !strings.Contains(out, "2. git checkout -b feature/login") {
+		t.Errorf("Unexpected plan:\n%s", out)
+	}
+	if branches := strings.TrimSpace(gitCommand(t, dir, "branch", "--list", "feature/*")); branches != "" {
+		t.Errorf("dry run created a branch: %s", branches)
+	}
+
+	if out, err := runFlow(t, binary, dir, "release", "start", "1.0.0"); err != nil {
+		t.Fatalf("release start failed: %v\nOutput: %s", err, out)
+	}
+	commitFile(t, dir, "VERSION", "1.0.0\n", "Bump version")
+	masterBefore := gitCommand(t, dir, "rev-parse", "master")
+
+	out, err = runFlow(t, binary, dir, "release", "finish", "1.0.0", "--dry-run")
+	if err != nil {
+		t.Fatalf("release finish --dry-run failed: %v\nOutput: %s", err, out)
+	}
+	for _, want := range []string{
+		"Pre-flight checks",
+		"1. git checkout master",
+		"2. git merge --no-ff release/1.0.0",
+		`3. git tag -a v1.0.0 -m "Release version 1.0.0"`,
+		"4. git checkout develop",
+		"5. git merge --no-ff release/1.0.0",
+		"6. git branch -d release/1.0.0",
+	} {
+		if !strings.Contains(out, want) {
+			t.Errorf("Plan missing %q:\n%s", want, out)
+		}
+	}
+
+	if got := gitCommand(t, dir, "rev-parse", "master"); got != masterBefore {
+		t.Error("dry run moved master")
+	}
+	if tags := strings.TrimSpace(gitCommand(t, dir, "tag", "-l")); tags != "" {
+		t.Errorf("dry run created tags: %s", tags)
+	}
+	if current := strings.TrimSpace(gitCommand(t, dir, "branch", "--show-current")); current != "release/1.0.0" {
+		t.Errorf("dry run switched branch to %s", current)
+	}
+}
+
+func TestDryRunReportsFailedPreflight(t *testing.T) {
+	binary := buildBinary(t)
+	dir := setupTestRepo(t)
+
+	if out, err := runFlow(t, binary, dir, "feature", "start", "login"); err != nil {
+		t.Fatalf("feature start failed: %v\nOutput: %s", err, out)
+	}
+	commitFile(t, dir, "login.go", "package main\n", "Add login")
+	if err := os.WriteFile(filepath.Join(dir, "README.md"), []byte("uncommitted"), testFileMode); err != nil {
+		t.Fatal(err)
+	}
+
+	out, err := runFlow(t, binary, dir, "feature", "finish", "login", "--dry-run")
+	if err == nil {
+		t.Fatalf("dry run should fail when pre-flight checks fail\nOutput: %s", out)
+	}
+	if !strings.Contains(out, "git merge --no-ff feature/login") || !strings.Contains(out, "plan would not run") {
+		t.Errorf("Output should show the plan and the failure:\n%s", out)
+	}
+}
@@ -46,6 +46,34 @@ func run(t *testing.T, dir string, name string, args ...string) {
 	}
 }
 
+// buildBinary builds gz-flow from the module root into a temp directory
+func buildBinary(t *testing.T) string {
+	t.Helper()
+
+	cwd, err := os.Getwd()
+	if err != nil {
+		t.Fatalf("Failed to get working directory: %v", err)
+	}
+	moduleRoot := filepath.Join(cwd, "..", "..")
+
+	binary := filepath.Join(t.TempDir(), "gz-flow")
+	buildCmd := exec.Command("go", "build", "-o", binary, "./cmd/gz-flow")
+	buildCmd.Dir = moduleRoot
+	if out, err := buildCmd.CombinedOutput(); err != nil {
+		t.Fatalf("Build failed: %v\n%s", err, out)
+	}
+	return binary
+}
+
+// runFlow runs the gz-flow binary in dir and returns its combined output
+func runFlow(t *testing.T, binary, dir string, args ...string) (string, error) {
+	t.Helper()
+	cmd := exec.Command(binary, args...)
+	cmd.Dir = dir
+	out, err := cmd.CombinedOutput()
+	return string(out), err
+}
+
 func TestFeatureStart(t *testing.T) {
 	t.Skip("Integration test requires built binary")
 
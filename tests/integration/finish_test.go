@@ -0,0 +1,158 @@
+// tests/integration/finish_test.go
+
+package integration
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+// setupConflictingRelease creates release/1.0.0 whose change to VERSION
+// conflicts with a later commit on develop
+func setupConflictingRelease(t *testing.T, binary string) string {
+	t.Helper()
+	dir := setupTestRepo(t)
+
+	if out, err := runFlow(t, binary, dir, "release", "start", "1.0.0"); err != nil {
+		t.Fatalf("release start failed: %v\nOutput: %s", err, out)
+	}
+	commitFile(t, dir, "VERSION", "1.0.0\n", "Bump version")
+
+	run(t, dir, "git", "checkout", "develop")
+	commitFile(t, dir, "VERSION", "2.0.0-dev\n", "Start next version")
+	run(t, dir, "git", "checkout", "release/1.0.0")
+	return dir
+}
+
+func TestReleaseFinishContinue(t *testing.T) {
+	binary := buildBinary(t)
+	dir := setupConflictingRelease(t, binary)
+
+	out, err := runFlow(t, binary, dir, "release", "finish", "1.0.0", "--allow-conflicts")
+	if err == nil {
+		t.Fatalf("release finish should stop on the develop conflict\nOutput: %s", out)
+	}
+	for _, want := range []string{"stopped at: git merge --no-ff release/1.0.0", "VERSION", "gz-flow release finish --continue"} {
+		if !strings.Contains(out, want) {
+			t.Errorf("Output missing %q:\n%s", want, out)
+		}
+	}
+	if _, err := os.Stat(filepath.Join(dir, ".git", "gz-flow", "state.json")); err != nil {
+		t.Fatalf("state file should be kept: %v", err)
+	}
+
+	// A new finish is refused while one is in progress
+	if out, err := runFlow(t, binary, dir, "release", "finish", "1.0.0", "--allow-conflicts"); err == nil || !strings.Contains(out, "already in progress") {
+		t.Errorf("Expected in-progress error, got: %s", out)
+	}
+
+	// Continuing with unresolved conflicts fails
+	if out, err := runFlow(t, binary, dir, "release", "finish", "--continue"); err == nil || !strings.Contains(out, "Unresolved conflicts") {
+		t.Errorf("Expected unresolved conflicts error, got: %s", out)
+	}
+
+	if err := os.WriteFile(filepath.Join(dir, "VERSION"), []byte("2.0.0-dev\n"), testFileMode); err != nil {
+		t.Fatal(err)
+	}
+	run(t, dir, "git", "add", "VERSION")
+
+	out, err = runFlow(t, binary, dir, "release", "finish", "--continue")
+	if err != nil {
+		t.Fatalf("release finish --continue failed: %v\nOutput: %s", err, out)
+	}
+
+	if tags := gitCommand(t, dir, "tag", "-l"); !strings.Contains(tags, "v1.0.0") {
+		t.Errorf("Tag v1.0.0 not found. Tags:\n%s", tags)
+	}
+	if branches := strings.TrimSpace(gitCommand(t, dir, "branch", "--list", "release/*")); branches != "" {
+		t.Errorf("release branch should be deleted, got: %s", branches)
+	}
+	if got := gitCommand(t, dir, "show", "develop:VERSION"); strings.TrimSpace(got) != "2.0.0-dev" {
+		t.Errorf("develop VERSION = %q, want the resolved content", got)
+	}
+	if !containsFile(t, dir, "master", "VERSION") {
+		t.Error("VERSION should be merged into master")
+	}
+	if _, err := os.Stat(filepath.Join(dir, ".git", "gz-flow", "state.json")); !os.IsNotExist(err) {
+		t.Error("state file should be removed after completion")
+	}
+}
+
+func TestReleaseFinishAbort(t *testing.T) {
+	binary := buildBinary(t)
+	dir := setupConflictingRelease(t, binary)
+
+	masterBefore := strings.TrimSpace(gitCommand(t, dir, "rev-parse", "master"))
+	developBefore := strings.TrimSpace(gitCommand(t, dir, "rev-parse", "develop"))
+
+	if out, err := runFlow(t, binary, dir, "release", "finish", "1.0.0", "--allow-conflicts"); err == nil {
+		t.Fatalf("release finish should stop on the develop conflict\nOutput: %s", out)
+	}
+
+	// Wrong operation is rejected
+	if out, err := runFlow(t, binary, dir, "hotfix", "finish", "--abort"); err == nil || !strings.Contains(out, "release finish") {
+		t.Errorf("Expected operation mismatch error, got: %s", out)
+	}
+
+	out, err := runFlow(t, binary, dir, "release", "finish", "--abort")
+	if err != nil {
+		t.Fatalf("release finish --abort failed: %v\nOutput: %s", err, out)
+	}
+
+	if got := strings.TrimSpace(gitCommand(t, dir, "rev-parse", "master")); got != masterBefore {
+		t.Errorf("master = %s, want %s", got, masterBefore)
+	}
+	if got := strings.TrimSpace(gitCommand(t, dir, "rev-parse", "develop")); got != developBefore {
+		t.Errorf("develop = %s, want %s", got, developBefore)
+	}
+	if tags := gitCommand(t, dir, "tag", "-l"); strings.Contains(tags, "v1.0.0") {
+		t.Errorf("Tag v1.0.0 should be deleted. Tags:\n%s", tags)
+	}
+	if current := strings.TrimSpace(gitCommand(t, dir, "branch", "--show-current")); current != "release/1.0.0" {
+		t.Errorf("current branch = %s, want release/1.0.0", current)
+	}
+	if status := strings.TrimSpace(gitCommand(t, dir, "status", "--porcelain")); status != "" {
+		t.Errorf("working tree should be clean:\n%s", status)
+	}
+	if _, err := os.Stat(filepath.Join(dir, ".git", "gz-flow", "state.json")); !os.IsNotExist(err) {
+		t.Error("state file should be removed after abort")
+	}
+}
+
+func TestReleaseFinishRollback(t *testing.T) {
+	binary := buildBinary(t)
+	dir := setupConflictingRelease(t, binary)
+
+	masterBefore := strings.TrimSpace(gitCommand(t, dir, "rev-parse", "master"))
+	developBefore := strings.TrimSpace(gitCommand(t, dir, "rev-parse", "develop"))
+
+	out, err := runFlow(t, binary, dir, "release", "finish", "1.0.0", "--allow-conflicts", "--rollback")
+	if err == nil || !strings.Contains(out, "rolled back") {
+		t.Fatalf("release finish should fail and roll back\nOutput: %s", out)
+	}
+	if !strings.Contains(out, "Restored 'master'") || !strings.Contains(out, "Deleted tag 'v1.0.0'") {
+		t.Errorf("Output should report the restored refs:\n%s", out)
+	}
+
+	if got := strings.TrimSpace(gitCommand(t, dir, "rev-parse", "master")); got != masterBefore {
+		t.Errorf("master = %s, want %s", got, masterBefore)
+	}
+	if got := strings.TrimSpace(gitCommand(t, dir, "rev-parse", "develop")); got != developBefore {
+		t.Errorf("develop = %s, want %s", got, developBefore)
+	}
+	if tags := gitCommand(t, dir, "tag", "-l"); strings.Contains(tags, "v1.0.0") {
+		t.Errorf("Tag v1.0.0 should be deleted. Tags:\n%s", tags)
+	}
+	if _, err := os.Stat(filepath.Join(dir, ".git", "gz-flow", "state.json")); !os.IsNotExist(err) {
+		t.Error("no state should be kept after a rollback")
+	}
+
+	// The release can be finished again once the conflict is gone
+	run(t, dir, "git", "checkout", "release/1.0.0")
+	run(t, dir, "git", "merge", "develop", "-X", "theirs", "-m", "Sync develop")
+	if out, err := runFlow(t, binary, dir, "release", "finish", "1.0.0"); err != nil {
+		t.Fatalf("release finish after rollback failed: %v\nOutput: %s", err, out)
+	}
+}
@@ -0,0 +1,146 @@
+// tests/integration/guardian_test.go
+
+package integration
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestGuardianPermissiveMode(t *testing.T) {
+	binary := buildBinary(t)
+	dir := setupTestRepo(t)
+	writeProjectConfig(t, dir, `guardian:
+  enabled: true
+  mode: permissive
+  naming:
+    forbidden: [wip]
+  workflow:
+    require_linear_history: true
+  severity:
+    require_linear_history: error
+`)
+
+	// Naming violations warn and proceed
+	out, err := runFlow(t, binary, dir, "feature", "start", "wip-login")
+	if err != nil {
+		t.Fatalf("feature start should proceed in permissive mode: %v\n%s", err, out)
+	}
+	if !strings.Contains(out, "⚠️  guardian: branch name contains forbidden word: wip") {
+		t.Errorf("Expected a naming warning:\n%s", out)
+	}
+	commitFile(t, dir, "wip.txt", "x\n", "Feature work")
+
+	// So does a dirty working tree
+	if err := os.WriteFile(filepath.Join(dir, "README.md"), []byte("local edit\n"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	out, err = runFlow(t, binary, dir, "feature", "finish", "wip-login")
+	if err != nil {
+		t.Fatalf("feature finish should proceed in permissive mode: %v\n%s", err, out)
+	}
+	if !strings.Contains(out, "⚠️  Clean working tree") || !strings.Contains(out, "Proceeding despite 1 guardian warning(s)") {
+		t.Errorf("Expected a clean tree warning:\n%s", out)
+	}
+	run(t, dir, "git", "checkout", "--", "README.md")
+
+	log, err := os.ReadFile(filepath.Join(dir, ".git", "gz-flow", "audit.log"))
+	if err != nil {
+		t.Fatalf("audit log not written: %v", err)
+	}
+	lines := strings.Split(strings.TrimSpace(string(log)), "\n")
+	if len(lines) != 2 ||
+		!strings.Contains(lines[0], `"operation":"feature start","rule":"naming","branch":"feature/wip-login"`) ||
+		!strings.Contains(lines[1], `"operation":"feature finish","rule":"clean-tree"`) ||
+		!strings.Contains(lines[1], `"user":"test@test.com"`) {
+		t.Errorf("Unexpected audit log:\n%s", log)
+	}
+
+	// A rule overridden to error still blocks
+	if out, err := runFlow(t, binary, dir, "feature", "start", "behind"); err != nil {
+		t.Fatalf("feature start failed: %v\nOutput: %s", err, out)
+	}
+	commitFile(t, dir, "behind.txt", "x\n", "More work")
+	run(t, dir, "git", "checkout", "develop")
+	commitFile(t, dir, "develop.txt", "y\n", "Develop work")
+	out, err = runFlow(t, binary, dir, "feature", "finish", "behind")
+	if err == nil || !strings.Contains(out, "❌ 'feature/behind' is rebased on 'develop'") {
+		t.Fatalf("linear history should block: %v\n%s", err, out)
+	}
+}
+
+func TestGuardianDisabledIgnoresMode(t *testing.T) {
+	binary := buildBinary(t)
+	dir := setupTestRepo(t)
+	writeProjectConfig(t, dir, `guardian:
+  enabled: false
+  mode: permissive
+  severity:
+    require_up_to_date: warn
+`)
+
+	if out, err := runFlow(t, binary, dir, "feature", "start", "login"); err != nil {
+		t.Fatalf("feature start failed: %v\nOutput: %s", err, out)
+	}
+	commitFile(t, dir, "login.txt", "x\n", "Feature work")
+
+	// Without guardian a dirty working tree still blocks
+	if err := os.WriteFile(filepath.Join(dir, "README.md"), []byte("local edit\n"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	out, err := runFlow(t, binary, dir, "feature", "finish", "login")
+	if err == nil || !strings.Contains(out, "❌ Clean working tree") || strings.Contains(out, "guardian warning") {
+		t.Fatalf("finish should block on a dirty tree: %v\n%s", err, out)
+	}
+	if containsFile(t, dir, "develop", "login.txt") {
+		t.Error("nothing should be merged when the pre-flight fails")
+	}
+	if _, err := os.Stat(filepath.Join(dir, ".git", "gz-flow", "audit.log")); !os.IsNotExist(err) {
+		t.Errorf("no audit log should be written without guardian: %v", err)
+	}
+}
+
+func TestGuardianSeverityOverrides(t *testing.T) {
+	binary := buildBinary(t)
+	dir := setupTestRepo(t)
+	writeProjectConfig(t, dir, `guardian:
+  enabled: true
+  naming:
+    forbidden: [wip]
+  severity:
+    naming: "off"
+    require_clean_tree: warn
+`)
+
+	out, err := runFlow(t, binary, dir, "feature", "start", "wip-login")
+	if err != nil || strings.Contains(out, "guardian") {
+		t.Fatalf("naming should not be checked: %v\n%s", err, out)
+	}
+	commitFile(t, dir, "wip.txt", "x\n", "Feature work")
+
+	// Strict mode still warns for untracked files, because the rule is warn
+	if err := os.WriteFile(filepath.Join(dir, "notes.txt"), []byte("scratch\n"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	structured, code := runFlowStructured(t, binary, dir, "feature", "finish", "wip-login", "-o", "json")
+	if code != 0 {
+		t.Fatalf("finish should proceed: exit %d\n%s", code, structured)
+	}
+	op := decodeOperation(t, structured)
+	warned := false
+	for _, r := range op.Preflight {
+		if r.Name == "No untracked files" && !r.Passed && r.Warning {
+			warned = true
+		}
+	}
+	if !warned {
+		t.Errorf("Expected an untracked files warning: %+v", op.Preflight)
+	}
+
+	out, err = runFlow(t, binary, dir, "config", "guardian.severity.naming", "block")
+	if err == nil || !strings.Contains(out, "guardian.severity.naming must be 'error', 'warn' or 'off'") {
+		t.Errorf("invalid severity should be rejected: %v\n%s", err, out)
+	}
+}
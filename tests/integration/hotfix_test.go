@@ -0,0 +1,132 @@
+// tests/integration/hotfix_test.go
+
+package integration
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+// commitFile writes a file and commits it on the current branch
+func commitFile(t *testing.T, dir, name, content, message string) {
+	t.Helper()
+	if err := os.WriteFile(filepath.Join(dir, name), []byte(content), testFileMode); err != nil {
+		t.Fatalf("Failed to write %s: %v", name, err)
+	}
+	run(t, dir, "git", "add", name)
+	run(t, dir, "git", "commit", "-m", message)
+}
+
+// containsFile reports whether branch contains path
+func containsFile(t *testing.T, dir, branch, path string) bool {
+	t.Helper()
+	out := gitCommand(t, dir, "ls-tree", "--name-only", branch, path)
+	return strings.TrimSpace(out) == path
+}
+
+func TestHotfixStartValidation(t *testing.T) {
+	binary := buildBinary(t)
+	dir := setupTestRepo(t)
+
+	out, err := runFlow(t, binary, dir, "hotfix", "start", "v1.0.1")
+	if err == nil || !strings.Contains(out, "invalid version") {
+		t.Errorf("Expected invalid version error, got: %s", out)
+	}
+
+	out, err = runFlow(t, binary, dir, "hotfix", "start", "1.0.1")
+	if err != nil {
+		t.Fatalf("hotfix start failed: %v\nOutput: %s", err, out)
+	}
+
+	// Hotfix must branch from master, not develop
+	run(t, dir, "git", "checkout", "develop")
+	commitFile(t, dir, "develop-only.txt", "dev", "Develop work")
+	if containsFile(t, dir, "hotfix/1.0.1", "develop-only.txt") {
+		t.Error("hotfix/1.0.1 should not contain develop commits")
+	}
+	base := gitCommand(t, dir, "merge-base", "hotfix/1.0.1", "master")
+	masterHead := gitCommand(t, dir, "rev-parse", "master")
+	if base != masterHead {
+		t.Errorf("hotfix/1.0.1 should start at master (%s), merge-base is %s", masterHead, base)
+	}
+}
+
+func TestHotfixWorkflow(t *testing.T) {
+	binary := buildBinary(t)
+	dir := setupTestRepo(t)
+
+	if out, err := runFlow(t, binary, dir, "hotfix", "start", "1.0.1"); err != nil {
+		t.Fatalf("hotfix start failed: %v\nOutput: %s", err, out)
+	}
+	commitFile(t, dir, "FIX.md", "fix", "Fix production bug")
+
+	out, err := runFlow(t, binary, dir, "hotfix", "finish", "1.0.1")
+	if err != nil {
+		t.Fatalf("hotfix finish failed: %v\nOutput: %s", err, out)
+	}
+
+	tags := gitCommand(t, dir, "tag", "-l")
+	if !strings.Contains(tags, "v1.0.1") {
+		t.Errorf("Tag v1.0.1 not found. Tags:\n%s", tags)
+	}
+	if !containsFile(t, dir, "master", "FIX.md") {
+		t.Error("FIX.md should be merged into master")
+	}
+	if !containsFile(t, dir, "develop", "FIX.md") {
+		t.Error("FIX.md should be merged into develop")
+	}
+	branches := gitCommand(t, dir, "branch", "--list", "hotfix/*")
+	if strings.TrimSpace(branches) != "" {
+		t.Errorf("Hotfix branch should be deleted. Branches:\n%s", branches)
+	}
+}
+
+func TestHotfixFinishMergesIntoActiveRelease(t *testing.T) {
+	binary := buildBinary(t)
+	dir := setupTestRepo(t)
+
+	if out, err := runFlow(t, binary, dir, "release", "start", "1.1.0"); err != nil {
+		t.Fatalf("release start failed: %v\nOutput: %s", err, out)
+	}
+	if out, err := runFlow(t, binary, dir, "hotfix", "start", "1.0.1"); err != nil {
+		t.Fatalf("hotfix start failed: %v\nOutput: %s", err, out)
+	}
+	commitFile(t, dir, "FIX.md", "fix", "Fix production bug")
+
+	out, err := runFlow(t, binary, dir, "hotfix", "finish", "1.0.1")
+	if err != nil {
+		t.Fatalf("hotfix finish failed: %v\nOutput: %s", err, out)
+	}
+
+	if !containsFile(t, dir, "release/1.1.0", "FIX.md") {
+		t.Errorf("FIX.md should be merged into the active release branch\nOutput: %s", out)
+	}
+	if containsFile(t, dir, "develop", "FIX.md") {
+		t.Error("develop should receive the fix through the release, not directly")
+	}
+}
+
+func TestHotfixFinishWithoutDevelop(t *testing.T) {
+	binary := buildBinary(t)
+	dir := setupTestRepo(t)
+
+	if out, err := runFlow(t, binary, dir, "hotfix", "start", "1.0.1"); err != nil {
+		t.Fatalf("hotfix start failed: %v\nOutput: %s", err, out)
+	}
+	commitFile(t, dir, "FIX.md", "fix", "Fix production bug")
+	run(t, dir, "git", "branch", "-D", "develop")
+
+	// Only master is checked and merged into
+	out, err := runFlow(t, binary, dir, "hotfix", "finish", "1.0.1")
+	if err != nil {
+		t.Fatalf("hotfix finish failed: %v\nOutput: %s", err, out)
+	}
+	if !strings.Contains(out, "💡 Skipping merge to develop") || strings.Contains(out, "''") {
+		t.Errorf("Expected the merge back to be skipped:\n%s", out)
+	}
+	if !containsFile(t, dir, "master", "FIX.md") {
+		t.Error("FIX.md should be merged into master")
+	}
+}
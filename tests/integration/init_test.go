@@ -0,0 +1,76 @@
+// tests/integration/init_test.go
+
+package integration
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestInit(t *testing.T) {
+	binary := buildBinary(t)
+
+	t.Run("creates develop and config", func(t *testing.T) {
+		dir := setupTestRepo(t)
+		run(t, dir, "git", "checkout", "master")
+		run(t, dir, "git", "branch", "-D", "develop")
+
+		out, err := runFlow(t, binary, dir, "init", "--defaults")
+		if err != nil {
+			t.Fatalf("init failed: %v\nOutput: %s", err, out)
+		}
+
+		branches := gitCommand(t, dir, "branch", "--list", "develop")
+		if !strings.Contains(branches, "develop") {
+			t.Errorf("develop branch should be created. Branches:\n%s", branches)
+		}
+
+		data, err := os.ReadFile(filepath.Join(dir, ".gzflow.yaml"))
+		if err != nil {
+			t.Fatalf(".gzflow.yaml should be written: %v", err)
+		}
+		if !strings.Contains(string(data), "master: master") {
+			t.Errorf("config should record master branch, got:\n%s", data)
+		}
+	})
+
+	t.Run("detects main", func(t *testing.T) {
+		dir := setupTestRepo(t)
+		run(t, dir, "git", "branch", "-m", "master", "main")
+
+		out, err := runFlow(t, binary, dir, "init", "--defaults")
+		if err != nil {
+			t.Fatalf("init failed: %v\nOutput: %s", err, out)
+		}
+
+		data, err := os.ReadFile(filepath.Join(dir, ".gzflow.yaml"))
+		if err != nil {
+			t.Fatalf(".gzflow.yaml should be written: %v", err)
+		}
+		if !strings.Contains(string(data), "master: main") {
+			t.Errorf("config should use 'main' as master branch, got:\n%s", data)
+		}
+	})
+
+	t.Run("refuses to overwrite without force", func(t *testing.T) {
+		dir := setupTestRepo(t)
+
+		if out, err := runFlow(t, binary, dir, "init", "--defaults"); err != nil {
+			t.Fatalf("first init failed: %v\nOutput: %s", err, out)
+		}
+
+		out, err := runFlow(t, binary, dir, "init", "--defaults")
+		if err == nil {
+			t.Fatalf("second init should fail without --force. Output: %s", out)
+		}
+		if !strings.Contains(out, "already initialized") {
+			t.Errorf("Expected 'already initialized' error, got: %s", out)
+		}
+
+		if out, err := runFlow(t, binary, dir, "init", "--defaults", "--force"); err != nil {
+			t.Fatalf("init --force failed: %v\nOutput: %s", err, out)
+		}
+	})
+}
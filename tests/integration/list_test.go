@@ -0,0 +1,61 @@
+// tests/integration/list_test.go
+
+package integration
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestList(t *testing.T) {
+	binary := buildBinary(t)
+	dir := setupTestRepo(t)
+
+	run(t, dir, "git", "checkout", "-b", "feature/done")
+	run(t, dir, "git", "commit", "--allow-empty", "-m", "Done work")
+	run(t, dir, "git", "checkout", "develop")
+	run(t, dir, "git", "merge", "--no-ff", "feature/done", "-m", "Merge feature/done")
+	// feature/ff is merged by fast-forward; develop then moves on after
+	// feature/fresh is started from it
+	run(t, dir, "git", "checkout", "-b", "feature/ff")
+	run(t, dir, "git", "commit", "--allow-empty", "-m", "FF work")
+	run(t, dir, "git", "checkout", "develop")
+	run(t, dir, "git", "merge", "--ff-only", "feature/ff")
+	run(t, dir, "git", "branch", "feature/fresh")
+	run(t, dir, "git", "commit", "--allow-empty", "-m", "Later work on develop")
+	run(t, dir, "git", "checkout", "-b", "feature/wip")
+	run(t, dir, "git", "commit", "--allow-empty", "-m", "WIP 1")
+	run(t, dir, "git", "commit", "--allow-empty", "-m", "WIP 2")
+
+	out, err := runFlow(t, binary, dir, "list", "feature", "--sort", "ahead")
+	if err != nil {
+		t.Fatalf("list failed: %v\nOutput: %s", err, out)
+	}
+
+	wip := strings.Index(out, "feature/wip")
+	done := strings.Index(out, "feature/done")
+	if wip < 0 || done < 0 || wip > done {
+		t.Errorf("Expected feature/wip listed before feature/done:\n%s", out)
+	}
+	if !strings.Contains(out, "2 ahead of develop") {
+		t.Errorf("Expected ahead count for feature/wip:\n%s", out)
+	}
+	if !strings.Contains(out, "0 ahead of develop, merged") {
+		t.Errorf("Expected feature/done marked as merged:\n%s", out)
+	}
+	for _, line := range strings.Split(out, "\n") {
+		if strings.Contains(line, "feature/fresh") && strings.Contains(line, "merged") {
+			t.Errorf("feature/fresh has no commits of its own and is not merged:\n%s", out)
+		}
+		if strings.Contains(line, "feature/ff") && !strings.Contains(line, "merged") {
+			t.Errorf("feature/ff is merged by fast-forward:\n%s", out)
+		}
+	}
+	if strings.Contains(out, "Release branches") {
+		t.Errorf("Type filter should hide release branches:\n%s", out)
+	}
+
+	if out, err := runFlow(t, binary, dir, "list", "bugfix"); err == nil {
+		t.Errorf("list with invalid type should fail. Output: %s", out)
+	}
+}
@@ -0,0 +1,226 @@
+// tests/integration/output_test.go
+
+package integration
+
+import (
+	"encoding/json"
+	"errors"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"gopkg.in/yaml.v3"
+)
+
+// runFlowStructured runs gz-flow and returns stdout only, plus the exit code
+func runFlowStructured(t *testing.T, binary, dir string, args ...string) ([]byte, int) {
+	t.Helper()
+	cmd := exec.Command(binary, args...)
+	cmd.Dir = dir
+	out, err := cmd.Output()
+	var exitErr *exec.ExitError
+	if errors.As(err, &exitErr) {
+		return out, exitErr.ExitCode()
+	}
+	if err != nil {
+		t.Fatalf("failed to run gz-flow: %v", err)
+	}
+	return out, 0
+}
+
+// operation mirrors the structured result of start/finish commands
+type operation struct {
+	Operation string   `json:"operation"`
+	Name      string   `json:"name"`
+	Status    string   `json:"status"`
+	Branch    string   `json:"branch"`
+	Tags      []string `json:"tags"`
+	Preflight []struct {
+		Name    string `json:"name"`
+		Passed  bool   `json:"passed"`
+		Warning bool   `json:"warning"`
+	} `json:"preflight"`
+	Completed []string `json:"completed"`
+	Pending   []string `json:"pending"`
+	Conflicts []string `json:"conflicts"`
+	Error     string   `json:"error"`
+	ExitCode  int      `json:"exit_code"`
+}
+
+func decodeOperation(t *testing.T, out []byte) operation {
+	t.Helper()
+	var op operation
+	if err := json.Unmarshal(out, &op); err != nil {
+		t.Fatalf("stdout is not JSON: %v\n%s", err, out)
+	}
+	return op
+}
+
+func TestOutputJSON(t *testing.T) {
+	binary := buildBinary(t)
+	dir := setupTestRepo(t)
+
+	out, code := runFlowStructured(t, binary, dir, "feature", "start", "login", "--output", "json")
+	if code != 0 {
+		t.Fatalf("feature start exited %d\n%s", code, out)
+	}
+	op := decodeOperation(t, out)
+	if op.Operation != "feature start" || op.Status != "success" || op.Branch != "feature/login" {
+		t.Errorf("Unexpected result: %+v", op)
+	}
+
+	var status struct {
+		Branch string              `json:"branch"`
+		Type   string              `json:"type"`
+		Active map[string][]string `json:"active"`
+		Clean  bool                `json:"clean"`
+	}
+	out, code = runFlowStructured(t, binary, dir, "status", "-o", "json")
+	if code != 0 {
+		t.Fatalf("status exited %d\n%s", code, out)
+	}
+	if err := json.Unmarshal(out, &status); err != nil {
+		t.Fatalf("status is not JSON: %v\n%s", err, out)
+	}
+	if status.Branch != "feature/login" || status.Type != "feature" || !status.Clean {
+		t.Errorf("Unexpected status: %+v", status)
+	}
+	if len(status.Active["feature"]) != 1 || len(status.Active["release"]) != 0 {
+		t.Errorf("Unexpected active branches: %v", status.Active)
+	}
+
+	var list struct {
+		Branches []struct {
+			Name string `json:"name"`
+			Type string `json:"type"`
+			Base string `json:"base"`
+		} `json:"branches"`
+	}
+	out, _ = runFlowStructured(t, binary, dir, "list", "-o", "json")
+	if err := json.Unmarshal(out, &list); err != nil {
+		t.Fatalf("list is not JSON: %v\n%s", err, out)
+	}
+	if len(list.Branches) != 1 || list.Branches[0].Name != "feature/login" || list.Branches[0].Base != "develop" {
+		t.Errorf("Unexpected list: %+v", list)
+	}
+
+	var entry struct {
+		Key    string `json:"key"`
+		Value  string `json:"value"`
+		Origin string `json:"origin"`
+	}
+	out, _ = runFlowStructured(t, binary, dir, "config", "branches.develop", "-o", "json")
+	if err := json.Unmarshal(out, &entry); err != nil {
+		t.Fatalf("config is not JSON: %v\n%s", err, out)
+	}
+	if entry.Key != "branches.develop" || entry.Value != "develop" || entry.Origin != "default" {
+		t.Errorf("Unexpected config entry: %+v", entry)
+	}
+
+	// Finish reports the preflight results and every command it ran
+	commitFile(t, dir, "login.go", "package main\n", "Add login")
+	out, code = runFlowStructured(t, binary, dir, "feature", "finish", "-o", "json")
+	if code != 0 {
+		t.Fatalf("feature finish exited %d\n%s", code, out)
+	}
+	op = decodeOperation(t, out)
+	if op.Name != "login" || op.Status != "success" || len(op.Preflight) == 0 || !op.Preflight[0].Passed {
+		t.Errorf("Unexpected result: %+v", op)
+	}
+	if strings.Join(op.Completed, "; ") != "git checkout develop; git merge --no-ff feature/login; git branch -d feature/login" {
+		t.Errorf("Unexpected completed steps: %v", op.Completed)
+	}
+}
+
+func TestOutputYAML(t *testing.T) {
+	binary := buildBinary(t)
+	dir := setupTestRepo(t)
+
+	out, code := runFlowStructured(t, binary, dir, "release", "start", "1.0.0", "--dry-run", "-o", "yaml")
+	if code != 0 {
+		t.Fatalf("release start exited %d\n%s", code, out)
+	}
+	var op operation
+	if err := yaml.Unmarshal(out, &op); err != nil {
+		t.Fatalf("stdout is not YAML: %v\n%s", err, out)
+	}
+	if op.Status != "dry_run" || len(op.Pending) != 2 {
+		t.Errorf("Unexpected result: %+v\n%s", op, out)
+	}
+}
+
+func TestExitCodes(t *testing.T) {
+	binary := buildBinary(t)
+
+	// Not a git repository
+	out, code := runFlowStructured(t, binary, t.TempDir(), "status", "-o", "json")
+	if code != 3 || !strings.Contains(string(out), `"exit_code": 3`) {
+		t.Errorf("not a repository: exit %d\n%s", code, out)
+	}
+
+	dir := setupTestRepo(t)
+
+	// Usage error
+	if _, code := runFlowStructured(t, binary, dir, "release", "start", "v1"); code != 2 {
+		t.Errorf("invalid version: exit %d, want 2", code)
+	}
+	if _, code := runFlowStructured(t, binary, dir, "status", "--output", "xml"); code != 2 {
+		t.Errorf("invalid output format: exit %d, want 2", code)
+	}
+
+	// Pre-flight failure
+	if out, err := runFlow(t, binary, dir, "feature", "start", "login"); err != nil {
+		t.Fatalf("feature start failed: %v\n%s", err, out)
+	}
+	if err := os.WriteFile(filepath.Join(dir, "README.md"), []byte("x"), testFileMode); err != nil {
+		t.Fatal(err)
+	}
+	out, code = runFlowStructured(t, binary, dir, "feature", "finish", "login", "-o", "json")
+	if code != 5 {
+		t.Errorf("pre-flight failure: exit %d, want 5\n%s", code, out)
+	}
+	op := decodeOperation(t, out)
+	failed := 0
+	for _, r := range op.Preflight {
+		if !r.Passed {
+			failed++
+		}
+	}
+	if op.Status != "failed" || failed != 1 {
+		t.Errorf("Unexpected result: %+v", op)
+	}
+}
+
+func TestExitCodeInterrupted(t *testing.T) {
+	binary := buildBinary(t)
+	dir := setupConflictingRelease(t, binary)
+
+	out, code := runFlowStructured(t, binary, dir, "release", "finish", "1.0.0", "--allow-conflicts", "-o", "json")
+	if code != 6 {
+		t.Fatalf("conflict: exit %d, want 6\n%s", code, out)
+	}
+	op := decodeOperation(t, out)
+	if op.Status != "interrupted" || len(op.Tags) != 1 || op.Tags[0] != "v1.0.0" {
+		t.Errorf("Unexpected result: %+v", op)
+	}
+	if len(op.Conflicts) != 1 || op.Conflicts[0] != "VERSION" {
+		t.Errorf("Unexpected conflicts: %v", op.Conflicts)
+	}
+	if len(op.Completed) != 4 || len(op.Pending) != 2 {
+		t.Errorf("Unexpected progress: completed %v, pending %v", op.Completed, op.Pending)
+	}
+
+	out, code = runFlowStructured(t, binary, dir, "release", "finish", "--abort", "-o", "json")
+	if code != 0 {
+		t.Fatalf("abort exited %d\n%s", code, out)
+	}
+	if op := decodeOperation(t, out); op.Status != "aborted" || op.Name != "1.0.0" {
+		t.Errorf("Unexpected result: %+v", op)
+	}
+
+	if _, code := runFlowStructured(t, binary, dir, "release", "finish", "1.0.0", "--allow-conflicts", "--rollback"); code != 7 {
+		t.Errorf("rollback: exit %d, want 7", code)
+	}
+}
@@ -0,0 +1,104 @@
+// tests/integration/pick_test.go
+
+package integration
+
+import (
+	"os/exec"
+	"strings"
+	"testing"
+)
+
+// runFlowInput runs gz-flow with input on stdin
+func runFlowInput(t *testing.T, binary, dir, input string, args ...string) (string, error) {
+	t.Helper()
+	cmd := exec.Command(binary, args...)
+	cmd.Dir = dir
+	cmd.Stdin = strings.NewReader(input)
+	out, err := cmd.CombinedOutput()
+	return string(out), err
+}
+
+func TestPickFinish(t *testing.T) {
+	binary := buildBinary(t)
+	dir := setupTestRepo(t)
+
+	run(t, dir, "git", "checkout", "-b", "feature/old", "develop")
+	commitAged(t, dir, 45, "Kim", "Old work")
+	run(t, dir, "git", "checkout", "-b", "feature/new", "develop")
+	commitFile(t, dir, "new.txt", "x\n", "New work")
+	commitFile(t, dir, "more.txt", "y\n", "More work")
+	run(t, dir, "git", "checkout", "develop")
+
+	// Without a terminal the branches are numbered, most recent first
+	out, err := runFlowInput(t, binary, dir, "3\n2\n", "feature", "finish", "--pick")
+	if err != nil {
+		t.Fatalf("feature finish --pick failed: %v\nOutput: %s", err, out)
+	}
+	for _, want := range []string{
+		"? Select feature branch to finish:",
+		"  1) feature/new (just now, 2 commits ahead)",
+		"  2) feature/old (6 weeks ago, 1 commit ahead, stale)",
+		"❌ '3' is not one of the choices",
+		"📍 Selected feature/old",
+	} {
+		if !strings.Contains(out, want) {
+			t.Errorf("Output missing %q:\n%s", want, out)
+		}
+	}
+	if branchExists(t, dir, "feature/old") || !branchExists(t, dir, "feature/new") {
+		t.Errorf("Only feature/old should be finished:\n%s", out)
+	}
+
+	// No answer, no finish
+	out, err = runFlowInput(t, binary, dir, "", "feature", "finish", "--pick")
+	if exitErr, ok := err.(*exec.ExitError); !ok || exitErr.ExitCode() != 2 || !strings.Contains(out, "no branch selected") {
+		t.Errorf("Expected a usage error (exit 2), got %v:\n%s", err, out)
+	}
+	out, err = runFlowInput(t, binary, dir, "1\n", "feature", "finish", "new", "--pick")
+	if err == nil || !strings.Contains(out, "--pick cannot be combined with a feature name") {
+		t.Errorf("Expected --pick to reject a name: %v\n%s", err, out)
+	}
+
+	// Release and hotfix finish pick versions
+	if out, err := runFlow(t, binary, dir, "release", "start", "1.2.0"); err != nil {
+		t.Fatalf("release start failed: %v\nOutput: %s", err, out)
+	}
+	out, err = runFlowInput(t, binary, dir, "1\n", "release", "finish", "--pick")
+	if err != nil || !strings.Contains(out, "📍 Selected release/1.2.0") {
+		t.Fatalf("release finish --pick failed: %v\nOutput: %s", err, out)
+	}
+	if tags := gitCommand(t, dir, "tag", "--list"); !strings.Contains(tags, "v1.2.0") {
+		t.Errorf("Expected tag v1.2.0, got: %s", tags)
+	}
+	out, err = runFlowInput(t, binary, dir, "1\n", "hotfix", "finish", "--pick")
+	if err == nil || !strings.Contains(out, "no hotfix branches to choose from") {
+		t.Errorf("Expected no hotfix branches: %v\n%s", err, out)
+	}
+}
+
+func TestCheckoutPick(t *testing.T) {
+	binary := buildBinary(t)
+	dir := setupTestRepo(t)
+
+	run(t, dir, "git", "branch", "feature/login", "develop")
+	run(t, dir, "git", "branch", "hotfix/1.0.1", "master")
+
+	out, err := runFlowInput(t, binary, dir, "1\n", "checkout", "hotfix")
+	if err != nil {
+		t.Fatalf("checkout failed: %v\nOutput: %s", err, out)
+	}
+	if strings.Contains(out, "feature/login") || !strings.Contains(out, "📍 Switched to branch 'hotfix/1.0.1'") {
+		t.Errorf("Expected only hotfix branches:\n%s", out)
+	}
+	if branch := gitCommand(t, dir, "branch", "--show-current"); strings.TrimSpace(branch) != "hotfix/1.0.1" {
+		t.Errorf("current branch = %s", branch)
+	}
+
+	out, err = runFlowInput(t, binary, dir, "1\n", "--dry-run", "checkout")
+	if err != nil || !strings.Contains(out, "git checkout feature/login") {
+		t.Fatalf("checkout --dry-run failed: %v\nOutput: %s", err, out)
+	}
+	if branch := gitCommand(t, dir, "branch", "--show-current"); strings.TrimSpace(branch) != "hotfix/1.0.1" {
+		t.Errorf("dry run switched to %s", branch)
+	}
+}
@@ -0,0 +1,349 @@
+// tests/integration/preflight_test.go
+
+package integration
+
+import (
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestFinishBehindRemote(t *testing.T) {
+	binary := buildBinary(t)
+	dir := setupTestRepo(t)
+	remote := addBareRemote(t, dir, "origin")
+	run(t, dir, "git", "push", "origin", "master", "develop")
+
+	if out, err := runFlow(t, binary, dir, "feature", "start", "behind"); err != nil {
+		t.Fatalf("feature start failed: %v\nOutput: %s", err, out)
+	}
+	commitFile(t, dir, "behind.txt", "x\n", "Feature work")
+
+	// A teammate pushes to develop after our last fetch
+	other := cloneRepo(t, remote)
+	run(t, other, "git", "checkout", "develop")
+	commitFile(t, other, "other.txt", "y\n", "Other work")
+	commitFile(t, other, "other2.txt", "y\n", "More other work")
+	run(t, other, "git", "push", "origin", "develop")
+
+	// --no-fetch compares with the stale remote-tracking branch
+	out, err := runFlow(t, binary, dir, "--dry-run", "feature", "finish", "behind", "--no-fetch")
+	if err != nil {
+		t.Fatalf("dry run with --no-fetch should pass: %v\n%s", err, out)
+	}
+
+	// A dry run never fetches, so it sees the same stale state
+	before := gitCommand(t, dir, "rev-parse", "origin/develop")
+	if out, err := runFlow(t, binary, dir, "--dry-run", "feature", "finish", "behind"); err != nil {
+		t.Fatalf("dry run should not fetch: %v\n%s", err, out)
+	}
+	if after := gitCommand(t, dir, "rev-parse", "origin/develop"); after != before {
+		t.Errorf("dry run updated origin/develop: %s -> %s", before, after)
+	}
+
+	out, err = runFlow(t, binary, dir, "feature", "finish", "behind")
+	if err == nil {
+		t.Fatalf("finish should fail while develop is behind origin\n%s", out)
+	}
+	if !strings.Contains(out, "develop is 2 commits behind origin/develop") {
+		t.Errorf("Expected behind count, got: %s", out)
+	}
+	if containsFile(t, dir, "develop", "behind.txt") {
+		t.Error("nothing should be merged when the pre-flight fails")
+	}
+
+	// Once develop is updated the finish goes through
+	run(t, dir, "git", "checkout", "develop")
+	run(t, dir, "git", "merge", "--ff-only", "origin/develop")
+	if out, err := runFlow(t, binary, dir, "feature", "finish", "behind"); err != nil {
+		t.Fatalf("finish after update failed: %v\n%s", err, out)
+	}
+
+	// The check follows guardian.workflow.require_up_to_date
+	if out, err := runFlow(t, binary, dir, "feature", "start", "unchecked"); err != nil {
+		t.Fatalf("feature start failed: %v\nOutput: %s", err, out)
+	}
+	commitFile(t, dir, "unchecked.txt", "x\n", "More feature work")
+	run(t, other, "git", "commit", "--allow-empty", "-m", "Even more work")
+	run(t, other, "git", "push", "origin", "develop")
+	for _, kv := range [][]string{{"guardian.enabled", "true"}, {"guardian.workflow.require_up_to_date", "false"}} {
+		if out, err := runFlow(t, binary, dir, "config", kv[0], kv[1]); err != nil {
+			t.Fatalf("config failed: %v\n%s", err, out)
+		}
+	}
+	if err := os.WriteFile(filepath.Join(dir, ".git", "info", "exclude"), []byte(".gzflow.yaml\n"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	out, err = runFlow(t, binary, dir, "feature", "finish", "unchecked")
+	if err != nil || strings.Contains(out, "up to date") {
+		t.Errorf("finish should skip the up-to-date check: %v\n%s", err, out)
+	}
+}
+
+func TestFinishOffline(t *testing.T) {
+	binary := buildBinary(t)
+	dir := setupTestRepo(t)
+	addBareRemote(t, dir, "origin")
+	run(t, dir, "git", "push", "origin", "master", "develop")
+	run(t, dir, "git", "remote", "set-url", "origin", filepath.Join(t.TempDir(), "missing.git"))
+
+	if out, err := runFlow(t, binary, dir, "feature", "start", "offline"); err != nil {
+		t.Fatalf("feature start failed: %v\nOutput: %s", err, out)
+	}
+	commitFile(t, dir, "offline.txt", "x\n", "Feature work")
+
+	// The failed fetch warns and the finish compares with the last fetch
+	out, err := runFlow(t, binary, dir, "feature", "finish", "offline")
+	if err != nil {
+		t.Fatalf("finish should proceed when the fetch fails: %v\n%s", err, out)
+	}
+	if !strings.Contains(out, "Fetch 'origin'") || !strings.Contains(out, "Comparing with the last fetch") {
+		t.Errorf("Expected a fetch warning, got: %s", out)
+	}
+	if strings.Contains(out, "guardian warning") {
+		t.Errorf("a failed fetch is no guardian violation: %s", out)
+	}
+	if !containsFile(t, dir, "develop", "offline.txt") {
+		t.Error("feature should be merged into develop")
+	}
+}
+
+func TestReleaseFinishPredictsConflicts(t *testing.T) {
+	binary := buildBinary(t)
+	dir := setupConflictingRelease(t, binary)
+	masterBefore := strings.TrimSpace(gitCommand(t, dir, "rev-parse", "master"))
+
+	out, err := runFlow(t, binary, dir, "release", "finish", "1.0.0")
+	if err == nil {
+		t.Fatalf("finish should refuse when a merge would conflict\n%s", out)
+	}
+	for _, want := range []string{
+		"✅ 'release/1.0.0' merges cleanly into 'master'",
+		"❌ 'release/1.0.0' merges cleanly into 'develop'",
+		"conflicts in VERSION",
+	} {
+		if !strings.Contains(out, want) {
+			t.Errorf("Output missing %q:\n%s", want, out)
+		}
+	}
+
+	if got := strings.TrimSpace(gitCommand(t, dir, "rev-parse", "master")); got != masterBefore {
+		t.Error("master should not be modified")
+	}
+	if current := strings.TrimSpace(gitCommand(t, dir, "branch", "--show-current")); current != "release/1.0.0" {
+		t.Errorf("current branch = %s, should stay on the release branch", current)
+	}
+	if _, err := os.Stat(filepath.Join(dir, ".git", "gz-flow", "state.json")); !os.IsNotExist(err) {
+		t.Error("no finish state should be saved")
+	}
+}
+
+func TestFeatureFinishMissingBranch(t *testing.T) {
+	binary := buildBinary(t)
+	dir := setupTestRepo(t)
+
+	out, err := runFlow(t, binary, dir, "feature", "finish", "nope")
+	if exitErr, ok := err.(*exec.ExitError); !ok || exitErr.ExitCode() != 1 || !strings.Contains(out, "feature branch 'feature/nope' does not exist") {
+		t.Errorf("Expected a missing branch error (exit 1), got %v:\n%s", err, out)
+	}
+	if strings.Contains(out, "merges cleanly") || strings.Contains(out, "git 2.38") {
+		t.Errorf("Pre-flight checks should not run for a missing branch:\n%s", out)
+	}
+}
+
+// writeProjectConfig commits a .gzflow.yaml on the current branch
+func writeProjectConfig(t *testing.T, dir, content string) {
+	t.Helper()
+	commitFile(t, dir, ".gzflow.yaml", content, "Configure gz-flow")
+}
+
+func TestCustomPreflightChecks(t *testing.T) {
+	binary := buildBinary(t)
+	dir := setupTestRepo(t)
+	run(t, dir, "git", "checkout", "develop")
+	writeProjectConfig(t, dir, `preflight:
+  custom:
+    - name: no TODO in CHANGELOG
+      run: "! grep -n TODO CHANGELOG.md"
+      hint: Finish the changelog before releasing
+      operations: [release finish]
+  feature_finish:
+    disable: [merge-conflicts]
+`)
+
+	if out, err := runFlow(t, binary, dir, "release", "start", "1.0.0"); err != nil {
+		t.Fatalf("release start failed: %v\nOutput: %s", err, out)
+	}
+	commitFile(t, dir, "CHANGELOG.md", "# 1.0.0\n- TODO: describe\n", "Draft changelog")
+
+	out, err := runFlow(t, binary, dir, "release", "finish", "1.0.0")
+	if err == nil {
+		t.Fatalf("release finish should fail the custom check\n%s", out)
+	}
+	for _, want := range []string{"❌ no TODO in CHANGELOG", "2:- TODO: describe", "💡 Finish the changelog before releasing"} {
+		if !strings.Contains(out, want) {
+			t.Errorf("Output missing %q:\n%s", want, out)
+		}
+	}
+
+	commitFile(t, dir, "CHANGELOG.md", "# 1.0.0\n- First release\n", "Complete changelog")
+	out, err = runFlow(t, binary, dir, "release", "finish", "1.0.0")
+	if err != nil {
+		t.Fatalf("release finish failed: %v\nOutput: %s", err, out)
+	}
+	if !strings.Contains(out, "✅ no TODO in CHANGELOG") {
+		t.Errorf("Expected the custom check to pass:\n%s", out)
+	}
+
+	// The custom check is limited to release finish; merge-conflicts is off for features
+	if out, err := runFlow(t, binary, dir, "feature", "start", "docs"); err != nil {
+		t.Fatalf("feature start failed: %v\nOutput: %s", err, out)
+	}
+	commitFile(t, dir, "CHANGELOG.md", "- TODO\n", "Feature notes")
+	out, err = runFlow(t, binary, dir, "--dry-run", "feature", "finish", "docs")
+	if err != nil {
+		t.Fatalf("feature finish dry run failed: %v\nOutput: %s", err, out)
+	}
+	if strings.Contains(out, "CHANGELOG") || strings.Contains(out, "merges cleanly") {
+		t.Errorf("Unexpected checks for feature finish:\n%s", out)
+	}
+}
+
+func TestPreflightConfigUnknownCheck(t *testing.T) {
+	binary := buildBinary(t)
+	dir := setupTestRepo(t)
+	run(t, dir, "git", "checkout", "develop")
+	writeProjectConfig(t, dir, "preflight:\n  disabled: [clean-tre]\n")
+
+	if out, err := runFlow(t, binary, dir, "feature", "start", "x"); err != nil {
+		t.Fatalf("feature start failed: %v\nOutput: %s", err, out)
+	}
+	out, code := runFlowStructured(t, binary, dir, "feature", "finish", "x", "-o", "json")
+	if code != 5 {
+		t.Fatalf("exit %d, want 5\n%s", code, out)
+	}
+	if !strings.Contains(string(out), "unknown pre-flight check: clean-tre") {
+		t.Errorf("Expected the unknown check to be reported:\n%s", out)
+	}
+}
+
+func TestPreflightRepositoryState(t *testing.T) {
+	binary := buildBinary(t)
+	dir := setupTestRepo(t)
+
+	if out, err := runFlow(t, binary, dir, "feature", "start", "state"); err != nil {
+		t.Fatalf("feature start failed: %v\nOutput: %s", err, out)
+	}
+	commitFile(t, dir, "state.txt", "x\n", "Feature work")
+
+	// A detached HEAD is refused even when the branch is named
+	run(t, dir, "git", "checkout", "--detach")
+	out, err := runFlow(t, binary, dir, "feature", "finish", "state")
+	if err == nil || !strings.Contains(out, "HEAD is detached") {
+		t.Fatalf("finish should refuse a detached HEAD: %v\n%s", err, out)
+	}
+	run(t, dir, "git", "checkout", "feature/state")
+
+	// Untracked files are refused in guardian strict mode, the default mode
+	writeProjectConfig(t, dir, "guardian:\n  enabled: true\n")
+	if err := os.WriteFile(filepath.Join(dir, "notes.txt"), []byte("scratch\n"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	out, err = runFlow(t, binary, dir, "feature", "finish", "state")
+	if err == nil || !strings.Contains(out, "untracked: notes.txt") {
+		t.Fatalf("finish should refuse untracked files in strict mode: %v\n%s", err, out)
+	}
+	if containsFile(t, dir, "develop", "state.txt") {
+		t.Error("nothing should be merged when the pre-flight fails")
+	}
+
+	if err := os.Remove(filepath.Join(dir, "notes.txt")); err != nil {
+		t.Fatal(err)
+	}
+	if out, err := runFlow(t, binary, dir, "feature", "finish", "state"); err != nil {
+		t.Fatalf("finish failed: %v\nOutput: %s", err, out)
+	}
+}
+
+func TestGuardianWorkflowRules(t *testing.T) {
+	binary := buildBinary(t)
+	dir := setupTestRepo(t)
+	writeProjectConfig(t, dir, `options:
+  require_clean_tree: false
+guardian:
+  enabled: true
+  workflow:
+    require_linear_history: true
+`)
+
+	if out, err := runFlow(t, binary, dir, "feature", "start", "linear"); err != nil {
+		t.Fatalf("feature start failed: %v\nOutput: %s", err, out)
+	}
+	commitFile(t, dir, "linear.txt", "x\n", "Feature work")
+	run(t, dir, "git", "checkout", "develop")
+	commitFile(t, dir, "other.txt", "y\n", "Other work")
+	run(t, dir, "git", "checkout", "feature/linear")
+
+	// Linear history requires a rebase onto develop first
+	out, err := runFlow(t, binary, dir, "feature", "finish", "linear")
+	if err == nil || !strings.Contains(out, "feature/linear is missing 1 commit of develop") {
+		t.Fatalf("finish should require a rebase: %v\n%s", err, out)
+	}
+
+	// options.require_clean_tree: false lets uncommitted changes through
+	run(t, dir, "git", "rebase", "develop")
+	if err := os.WriteFile(filepath.Join(dir, "README.md"), []byte("local edit\n"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	out, err = runFlow(t, binary, dir, "feature", "finish", "linear")
+	if err != nil {
+		t.Fatalf("finish failed: %v\nOutput: %s", err, out)
+	}
+	if !strings.Contains(out, "✅ 'feature/linear' is rebased on 'develop'") || strings.Contains(out, "Clean working tree") {
+		t.Errorf("Unexpected pre-flight results:\n%s", out)
+	}
+
+	// develop is fast-forwarded to the rebased feature, without a merge commit
+	if merges := gitCommand(t, dir, "rev-list", "--merges", "develop"); strings.TrimSpace(merges) != "" {
+		t.Errorf("develop should have no merge commits, got: %s", merges)
+	}
+	if !containsFile(t, dir, "develop", "linear.txt") {
+		t.Error("linear.txt should be merged into develop")
+	}
+}
+
+func TestGuardianPreventDirectPush(t *testing.T) {
+	binary := buildBinary(t)
+	dir := setupTestRepo(t)
+	writeProjectConfig(t, dir, `guardian:
+  enabled: true
+  workflow:
+    prevent_direct_push: true
+`)
+
+	if out, err := runFlow(t, binary, dir, "feature", "start", "direct"); err != nil {
+		t.Fatalf("feature start failed: %v\nOutput: %s", err, out)
+	}
+	commitFile(t, dir, "direct.txt", "x\n", "Feature work")
+
+	// Pushing after a finish contradicts the rule; it is a configuration error
+	cmd := exec.Command(binary, "feature", "finish", "direct")
+	cmd.Dir = dir
+	cmd.Env = append(os.Environ(), "GZFLOW_OPTIONS_PUSH_AFTER_FINISH=true")
+	out, err := cmd.CombinedOutput()
+	if exitErr, ok := err.(*exec.ExitError); !ok || exitErr.ExitCode() != 4 ||
+		!strings.Contains(string(out), "options.push_after_finish and guardian.workflow.prevent_direct_push cannot both be on") {
+		t.Fatalf("finish should refuse the configuration (exit 4): %v\n%s", err, out)
+	}
+	if containsFile(t, dir, "develop", "direct.txt") {
+		t.Error("nothing should be merged with an invalid configuration")
+	}
+
+	// config refuses to save the combination
+	setOut, err := runFlow(t, binary, dir, "config", "options.push_after_finish", "true")
+	if err == nil || !strings.Contains(setOut, "cannot both be on") {
+		t.Errorf("config should reject push_after_finish: %v\n%s", err, setOut)
+	}
+}
@@ -0,0 +1,83 @@
+// tests/integration/publish_test.go
+
+package integration
+
+import (
+	"strings"
+	"testing"
+)
+
+// addBareRemote creates a bare repository and registers it as a remote of dir
+func addBareRemote(t *testing.T, dir, name string) string {
+	t.Helper()
+	remote := t.TempDir()
+	run(t, remote, "git", "init", "--bare", "--initial-branch=master")
+	run(t, dir, "git", "remote", "add", name, remote)
+	return remote
+}
+
+func TestFeaturePublish(t *testing.T) {
+	binary := buildBinary(t)
+	dir := setupTestRepo(t)
+	remote := addBareRemote(t, dir, "origin")
+
+	if out, err := runFlow(t, binary, dir, "feature", "start", "login"); err != nil {
+		t.Fatalf("feature start failed: %v\nOutput: %s", err, out)
+	}
+	commitFile(t, dir, "login.go", "package main\n", "Add login")
+
+	out, err := runFlow(t, binary, dir, "feature", "publish")
+	if err != nil {
+		t.Fatalf("feature publish failed: %v\nOutput: %s", err, out)
+	}
+	if !strings.Contains(out, "Published 'feature/login' to 'origin'") {
+		t.Errorf("Unexpected output:\n%s", out)
+	}
+
+	local := strings.TrimSpace(gitCommand(t, dir, "rev-parse", "feature/login"))
+	if pushed := strings.TrimSpace(gitCommand(t, remote, "rev-parse", "feature/login")); pushed != local {
+		t.Errorf("remote feature/login = %s, want %s", pushed, local)
+	}
+	if upstream := strings.TrimSpace(gitCommand(t, dir, "rev-parse", "--abbrev-ref", "feature/login@{upstream}")); upstream != "origin/feature/login" {
+		t.Errorf("upstream = %s, want origin/feature/login", upstream)
+	}
+}
+
+func TestPublishRemoteSelection(t *testing.T) {
+	binary := buildBinary(t)
+	dir := setupTestRepo(t)
+
+	if out, err := runFlow(t, binary, dir, "release", "start", "1.0.0"); err != nil {
+		t.Fatalf("release start failed: %v\nOutput: %s", err, out)
+	}
+
+	// No remote configured
+	out, err := runFlow(t, binary, dir, "release", "publish", "1.0.0")
+	if err == nil || !strings.Contains(out, "remote 'origin' does not exist") {
+		t.Errorf("Expected missing remote error, got: %s", out)
+	}
+
+	// --remote and options.remote pick another remote
+	upstream := addBareRemote(t, dir, "upstream")
+	out, err = runFlow(t, binary, dir, "release", "publish", "1.0.0", "--remote", "upstream")
+	if err != nil {
+		t.Fatalf("release publish --remote failed: %v\nOutput: %s", err, out)
+	}
+	if branches := gitCommand(t, upstream, "branch", "--list", "release/1.0.0"); !strings.Contains(branches, "release/1.0.0") {
+		t.Errorf("release/1.0.0 not pushed to upstream: %s", branches)
+	}
+
+	if out, err := runFlow(t, binary, dir, "hotfix", "start", "0.9.1"); err != nil {
+		t.Fatalf("hotfix start failed: %v\nOutput: %s", err, out)
+	}
+	if out, err := runFlow(t, binary, dir, "config", "options.remote", "upstream"); err != nil {
+		t.Fatalf("config set failed: %v\nOutput: %s", err, out)
+	}
+	out, err = runFlow(t, binary, dir, "hotfix", "publish", "--dry-run")
+	if err != nil || !strings.Contains(out, "git push --set-upstream upstream hotfix/0.9.1") {
+		t.Errorf("Unexpected dry run: %v\n%s", err, out)
+	}
+	if branches := gitCommand(t, upstream, "branch", "--list", "hotfix/*"); strings.TrimSpace(branches) != "" {
+		t.Errorf("dry run pushed: %s", branches)
+	}
+}
@@ -0,0 +1,155 @@
+// tests/integration/pull_test.go
+
+package integration
+
+import (
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+// cloneRepo clones remote into a new directory with a test identity
+func cloneRepo(t *testing.T, remote string) string {
+	t.Helper()
+	dir := t.TempDir()
+	cmd := exec.Command("git", "clone", remote, dir)
+	if out, err := cmd.CombinedOutput(); err != nil {
+		t.Fatalf("git clone failed: %v\n%s", err, out)
+	}
+	run(t, dir, "git", "config", "user.email", "teammate@test.com")
+	run(t, dir, "git", "config", "user.name", "Teammate")
+	return dir
+}
+
+// setupSharedFeature publishes feature/shared from one clone and tracks it
+// from a second clone, returning both working directories
+func setupSharedFeature(t *testing.T, binary string) (string, string) {
+	t.Helper()
+	alice := setupTestRepo(t)
+	remote := addBareRemote(t, alice, "origin")
+	run(t, alice, "git", "push", "origin", "master", "develop")
+
+	if out, err := runFlow(t, binary, alice, "feature", "start", "shared"); err != nil {
+		t.Fatalf("feature start failed: %v\nOutput: %s", err, out)
+	}
+	commitFile(t, alice, "shared.txt", "one\n", "Start shared work")
+	if out, err := runFlow(t, binary, alice, "feature", "publish"); err != nil {
+		t.Fatalf("feature publish failed: %v\nOutput: %s", err, out)
+	}
+
+	bob := cloneRepo(t, remote)
+	out, err := runFlow(t, binary, bob, "feature", "track", "shared")
+	if err != nil {
+		t.Fatalf("feature track failed: %v\nOutput: %s", err, out)
+	}
+	return alice, bob
+}
+
+func TestFeatureTrack(t *testing.T) {
+	binary := buildBinary(t)
+	alice, bob := setupSharedFeature(t, binary)
+
+	if current := strings.TrimSpace(gitCommand(t, bob, "branch", "--show-current")); current != "feature/shared" {
+		t.Errorf("current branch = %s, want feature/shared", current)
+	}
+	if upstream := strings.TrimSpace(gitCommand(t, bob, "rev-parse", "--abbrev-ref", "feature/shared@{upstream}")); upstream != "origin/feature/shared" {
+		t.Errorf("upstream = %s", upstream)
+	}
+	if !containsFile(t, bob, "feature/shared", "shared.txt") {
+		t.Error("tracked branch should contain the published work")
+	}
+
+	out, err := runFlow(t, binary, bob, "feature", "track", "shared")
+	if err == nil || !strings.Contains(out, "already exists") {
+		t.Errorf("Expected already exists error, got: %s", out)
+	}
+	out, err = runFlow(t, binary, alice, "feature", "track", "missing")
+	if err == nil || !strings.Contains(out, "origin/feature/missing") {
+		t.Errorf("Expected missing remote branch error, got: %s", out)
+	}
+}
+
+func TestFeaturePull(t *testing.T) {
+	binary := buildBinary(t)
+	alice, bob := setupSharedFeature(t, binary)
+
+	out, err := runFlow(t, binary, bob, "feature", "pull")
+	if err != nil || !strings.Contains(out, "up to date") {
+		t.Fatalf("Expected up to date: %v\n%s", err, out)
+	}
+
+	// Merge remote changes
+	commitFile(t, alice, "alice.txt", "a\n", "Alice work")
+	run(t, alice, "git", "push")
+	out, err = runFlow(t, binary, bob, "feature", "pull")
+	if err != nil {
+		t.Fatalf("feature pull failed: %v\nOutput: %s", err, out)
+	}
+	if !containsFile(t, bob, "feature/shared", "alice.txt") {
+		t.Error("pull should bring in alice.txt")
+	}
+
+	// Rebase local commits onto remote changes
+	commitFile(t, alice, "alice2.txt", "a\n", "More Alice work")
+	run(t, alice, "git", "push")
+	commitFile(t, bob, "bob.txt", "b\n", "Bob work")
+	out, err = runFlow(t, binary, bob, "feature", "pull", "--rebase")
+	if err != nil {
+		t.Fatalf("feature pull --rebase failed: %v\nOutput: %s", err, out)
+	}
+	if merges := strings.TrimSpace(gitCommand(t, bob, "rev-list", "--merges", "origin/feature/shared..feature/shared")); merges != "" {
+		t.Errorf("rebase should not create merge commits: %s", merges)
+	}
+	if count := strings.TrimSpace(gitCommand(t, bob, "rev-list", "--count", "origin/feature/shared..feature/shared")); count != "1" {
+		t.Errorf("expected 1 local commit on top of the remote, got %s", count)
+	}
+
+	// Untracked files do not block a pull
+	if err := os.WriteFile(filepath.Join(bob, "notes.txt"), []byte("n\n"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	commitFile(t, alice, "alice3.txt", "a\n", "Even more Alice work")
+	run(t, alice, "git", "push")
+	out, err = runFlow(t, binary, bob, "feature", "pull")
+	if err != nil {
+		t.Fatalf("pull with an untracked file failed: %v\n%s", err, out)
+	}
+	if !containsFile(t, bob, "feature/shared", "alice3.txt") {
+		t.Error("pull should bring in alice3.txt")
+	}
+
+	// A branch missing on the remote fails before switching to it
+	run(t, bob, "git", "branch", "feature/local")
+	out, err = runFlow(t, binary, bob, "feature", "pull", "local")
+	if err == nil || !strings.Contains(out, "gz-flow feature publish") {
+		t.Errorf("Expected missing remote branch error, got: %s", out)
+	}
+	if current := strings.TrimSpace(gitCommand(t, bob, "branch", "--show-current")); current != "feature/shared" {
+		t.Errorf("failed pull should stay on feature/shared, got %s", current)
+	}
+}
+
+func TestFeaturePullConflict(t *testing.T) {
+	binary := buildBinary(t)
+	alice, bob := setupSharedFeature(t, binary)
+
+	commitFile(t, alice, "shared.txt", "alice\n", "Alice edit")
+	run(t, alice, "git", "push")
+	commitFile(t, bob, "shared.txt", "bob\n", "Bob edit")
+
+	out, code := runFlowStructured(t, binary, bob, "feature", "pull", "-o", "json")
+	if code != 6 {
+		t.Fatalf("conflicting pull: exit %d, want 6\n%s", code, out)
+	}
+	op := decodeOperation(t, out)
+	if op.Status != "interrupted" || len(op.Conflicts) != 1 || op.Conflicts[0] != "shared.txt" {
+		t.Errorf("Unexpected result: %+v", op)
+	}
+
+	text, _ := runFlow(t, binary, bob, "status")
+	if !strings.Contains(text, "shared.txt") {
+		t.Errorf("conflicting file should show in status:\n%s", text)
+	}
+}
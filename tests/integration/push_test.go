@@ -0,0 +1,123 @@
+// tests/integration/push_test.go
+
+package integration
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+// enablePushAfterFinish turns on options.push_after_finish for the repository
+func enablePushAfterFinish(t *testing.T, binary, dir string) {
+	t.Helper()
+	if out, err := runFlow(t, binary, dir, "config", "options.push_after_finish", "true"); err != nil {
+		t.Fatalf("config set failed: %v\nOutput: %s", err, out)
+	}
+	// Keep the project config out of the working tree status
+	exclude := filepath.Join(dir, ".git", "info", "exclude")
+	if err := os.WriteFile(exclude, []byte(".gzflow.yaml\n"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestReleaseFinishPush(t *testing.T) {
+	binary := buildBinary(t)
+	dir := setupTestRepo(t)
+	remote := addBareRemote(t, dir, "origin")
+	run(t, dir, "git", "push", "origin", "master", "develop")
+	enablePushAfterFinish(t, binary, dir)
+
+	if out, err := runFlow(t, binary, dir, "release", "start", "1.0.0"); err != nil {
+		t.Fatalf("release start failed: %v\nOutput: %s", err, out)
+	}
+	commitFile(t, dir, "VERSION", "1.0.0\n", "Bump version")
+	if out, err := runFlow(t, binary, dir, "release", "publish"); err != nil {
+		t.Fatalf("release publish failed: %v\nOutput: %s", err, out)
+	}
+
+	out, err := runFlow(t, binary, dir, "release", "finish", "1.0.0")
+	if err != nil {
+		t.Fatalf("release finish failed: %v\nOutput: %s", err, out)
+	}
+	if !strings.Contains(out, "Pushed tag 'v1.0.0'") {
+		t.Errorf("Expected push output, got: %s", out)
+	}
+
+	for _, ref := range []string{"master", "develop"} {
+		local := strings.TrimSpace(gitCommand(t, dir, "rev-parse", ref))
+		pushed := strings.TrimSpace(gitCommand(t, remote, "rev-parse", ref))
+		if local != pushed {
+			t.Errorf("%s was not pushed: local %s, remote %s", ref, local, pushed)
+		}
+	}
+	if tags := strings.TrimSpace(gitCommand(t, remote, "tag", "-l", "v1.0.0")); tags != "v1.0.0" {
+		t.Error("tag v1.0.0 should be pushed")
+	}
+	if branches := strings.TrimSpace(gitCommand(t, remote, "branch", "--list", "release/1.0.0")); branches != "" {
+		t.Error("remote release branch should be deleted")
+	}
+}
+
+func TestFeatureFinishPushUnpublished(t *testing.T) {
+	binary := buildBinary(t)
+	dir := setupTestRepo(t)
+	remote := addBareRemote(t, dir, "origin")
+	run(t, dir, "git", "push", "origin", "master", "develop")
+	enablePushAfterFinish(t, binary, dir)
+
+	if out, err := runFlow(t, binary, dir, "feature", "start", "local"); err != nil {
+		t.Fatalf("feature start failed: %v\nOutput: %s", err, out)
+	}
+	commitFile(t, dir, "local.txt", "x\n", "Local work")
+
+	// Never published, so there is no remote branch to delete
+	out, err := runFlow(t, binary, dir, "--dry-run", "feature", "finish", "local")
+	if err != nil || !strings.Contains(out, "git push origin develop") || strings.Contains(out, "--delete") {
+		t.Fatalf("Unexpected dry run: %v\n%s", err, out)
+	}
+	if out, err := runFlow(t, binary, dir, "feature", "finish", "local"); err != nil {
+		t.Fatalf("feature finish failed: %v\nOutput: %s", err, out)
+	}
+	if !containsFile(t, remote, "develop", "local.txt") {
+		t.Error("develop should be pushed")
+	}
+}
+
+func TestFinishPushFailure(t *testing.T) {
+	binary := buildBinary(t)
+	dir := setupTestRepo(t)
+	remote := addBareRemote(t, dir, "origin")
+	run(t, dir, "git", "push", "origin", "master", "develop")
+	enablePushAfterFinish(t, binary, dir)
+
+	// Someone else pushes to develop, so our push is rejected
+	other := cloneRepo(t, remote)
+	run(t, other, "git", "checkout", "develop")
+	commitFile(t, other, "other.txt", "y\n", "Other work")
+	run(t, other, "git", "push", "origin", "develop")
+
+	if out, err := runFlow(t, binary, dir, "feature", "start", "rejected"); err != nil {
+		t.Fatalf("feature start failed: %v\nOutput: %s", err, out)
+	}
+	commitFile(t, dir, "mine.txt", "z\n", "My work")
+
+	// --no-fetch keeps the pre-flight from noticing, so the push is what fails
+	out, code := runFlowStructured(t, binary, dir, "feature", "finish", "rejected", "--no-fetch", "-o", "json")
+	if code != 8 {
+		t.Fatalf("push failure: exit %d, want 8\n%s", code, out)
+	}
+	op := decodeOperation(t, out)
+	if op.Status != "partial" || len(op.Pending) != 1 || op.Pending[0] != "git push origin develop" {
+		t.Errorf("Unexpected result: %+v", op)
+	}
+
+	// The local finish stands and nothing is left to continue
+	if !containsFile(t, dir, "develop", "mine.txt") {
+		t.Error("feature should stay merged into develop")
+	}
+	if _, err := os.Stat(filepath.Join(dir, ".git", "gz-flow", "state.json")); !os.IsNotExist(err) {
+		t.Error("no finish state should be kept after a push failure")
+	}
+}
@@ -0,0 +1,70 @@
+// tests/integration/risk_test.go
+
+package integration
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestConflictRiskWarning(t *testing.T) {
+	binary := buildBinary(t)
+	dir := setupTestRepo(t)
+
+	run(t, dir, "git", "checkout", "-b", "feature/payment", "develop")
+	commitFile(t, dir, "login.go", "payment\n", "Touch login")
+	commitAged(t, dir, 2, "Park", "Payment")
+	run(t, dir, "git", "checkout", "-b", "feature/other", "develop")
+	commitFile(t, dir, "other.go", "other\n", "Unrelated")
+	run(t, dir, "git", "checkout", "develop")
+
+	// Uncommitted changes carried to a new feature are compared
+	if err := os.WriteFile(filepath.Join(dir, "login.go"), []byte("auth\n"), testFileMode); err != nil {
+		t.Fatal(err)
+	}
+	run(t, dir, "git", "add", "login.go")
+	out, err := runFlow(t, binary, dir, "feature", "start", "user-auth")
+	if err != nil {
+		t.Fatalf("feature start failed: %v\nOutput: %s", err, out)
+	}
+	if !strings.Contains(out, "🔍 Conflict Risk Analysis") ||
+		!strings.Contains(out, "⚠️  login.go - also modified in:\n   └─ feature/payment (Park, 2 days ago)") ||
+		strings.Contains(out, "feature/other") {
+		t.Errorf("Expected a warning about feature/payment only:\n%s", out)
+	}
+	run(t, dir, "git", "commit", "-m", "Auth")
+
+	// Finish warns but proceeds
+	structured, code := runFlowStructured(t, binary, dir, "feature", "finish", "user-auth", "-o", "json")
+	if code != 0 {
+		t.Fatalf("feature finish should proceed: exit %d\n%s", code, structured)
+	}
+	var report struct {
+		Overlaps []struct {
+			Path   string `json:"path"`
+			Branch string `json:"branch"`
+			Author string `json:"author"`
+		} `json:"overlaps"`
+	}
+	if err := json.Unmarshal(structured, &report); err != nil {
+		t.Fatalf("invalid JSON: %v\n%s", err, structured)
+	}
+	if len(report.Overlaps) != 1 || report.Overlaps[0].Path != "login.go" ||
+		report.Overlaps[0].Branch != "feature/payment" || report.Overlaps[0].Author != "Park" {
+		t.Errorf("Expected the overlap in the structured result: %+v", report.Overlaps)
+	}
+
+	// No overlap, no analysis
+	out, err = runFlow(t, binary, dir, "feature", "start", "quiet")
+	if err != nil {
+		t.Fatalf("feature start failed: %v\nOutput: %s", err, out)
+	}
+	commitFile(t, dir, "quiet.go", "q\n", "Quiet")
+	out, err = runFlow(t, binary, dir, "feature", "finish", "quiet")
+	if err != nil || strings.Contains(out, "Conflict Risk") {
+		t.Errorf("Expected no conflict risk: %v\n%s", err, out)
+	}
+}
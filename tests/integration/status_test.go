@@ -0,0 +1,39 @@
+// tests/integration/status_test.go
+
+package integration
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestStatus(t *testing.T) {
+	binary := buildBinary(t)
+	dir := setupTestRepo(t)
+
+	run(t, dir, "git", "checkout", "-b", "feature/login")
+	run(t, dir, "git", "commit", "--allow-empty", "-m", "Add login")
+	if err := os.WriteFile(filepath.Join(dir, "wip.txt"), []byte("wip"), testFileMode); err != nil {
+		t.Fatalf("Failed to create file: %v", err)
+	}
+
+	out, err := runFlow(t, binary, dir, "status")
+	if err != nil {
+		t.Fatalf("status failed: %v\nOutput: %s", err, out)
+	}
+
+	for _, want := range []string{
+		"Current branch: feature/login",
+		"Branch type: feature (base: develop)",
+		"vs develop: 1 ahead, 0 behind",
+		"feature: feature/login",
+		"Working directory: 1 changed file(s)",
+		"?? wip.txt",
+	} {
+		if !strings.Contains(out, want) {
+			t.Errorf("Expected %q in output:\n%s", want, out)
+		}
+	}
+}
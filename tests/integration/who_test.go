@@ -0,0 +1,73 @@
+// tests/integration/who_test.go
+
+package integration
+
+import (
+	"encoding/json"
+	"strings"
+	"testing"
+)
+
+func TestWho(t *testing.T) {
+	binary := buildBinary(t)
+	dir := setupTestRepo(t)
+
+	run(t, dir, "git", "checkout", "-b", "feature/login", "develop")
+	commitFile(t, dir, "auth.go", "a\nb\nc\n", "Login")
+	run(t, dir, "git", "checkout", "-b", "feature/logout", "develop")
+	commitFile(t, dir, "auth.go", "x\n", "Logout")
+	commitFile(t, dir, "session.go", "s\n", "Session")
+	run(t, dir, "git", "checkout", "-b", "hotfix/1.0.1", "master")
+	commitFile(t, dir, "docs.md", "d\n", "Docs")
+
+	// Changes merged into develop after the features started do not count
+	run(t, dir, "git", "checkout", "develop")
+	commitFile(t, dir, "auth.go", "base\n", "Develop change")
+	run(t, dir, "git", "checkout", "feature/logout")
+
+	out, err := runFlow(t, binary, dir, "who", "auth.go")
+	if err != nil {
+		t.Fatalf("who failed: %v\nOutput: %s", err, out)
+	}
+	login := strings.Index(out, "feature/login   (+3, -0)")
+	logout := strings.Index(out, "feature/logout  (+1, -0)")
+	if !strings.Contains(out, "📁 auth.go modified in:") || login < 0 || logout < login {
+		t.Errorf("Expected both features, most changed first:\n%s", out)
+	}
+	if !strings.Contains(out, "← current") || strings.Contains(out, "develop  (") {
+		t.Errorf("Expected only flow branches and a current marker:\n%s", out)
+	}
+	if !strings.Contains(out, "2 branches modify this path") {
+		t.Errorf("Expected an overlap warning:\n%s", out)
+	}
+
+	out, err = runFlow(t, binary, dir, "who", "README.md")
+	if err != nil || !strings.Contains(out, "📁 README.md is not modified in any flow branch") {
+		t.Errorf("Expected no branches: %v\n%s", err, out)
+	}
+
+	// Directories sum up their files
+	structured, code := runFlowStructured(t, binary, dir, "who", ".", "-o", "json")
+	if code != 0 {
+		t.Fatalf("who -o json failed: exit %d\n%s", code, structured)
+	}
+	var report struct {
+		Path     string `json:"path"`
+		Branches []struct {
+			Branch  string `json:"branch"`
+			Files   int    `json:"files"`
+			Added   int    `json:"added"`
+			Current bool   `json:"current"`
+		} `json:"branches"`
+	}
+	if err := json.Unmarshal(structured, &report); err != nil {
+		t.Fatalf("invalid JSON: %v\n%s", err, structured)
+	}
+	if report.Path != "." || len(report.Branches) != 3 {
+		t.Fatalf("Unexpected report: %+v", report)
+	}
+	logoutEntry := report.Branches[1]
+	if logoutEntry.Branch != "feature/logout" || logoutEntry.Files != 2 || logoutEntry.Added != 2 || !logoutEntry.Current {
+		t.Errorf("Unexpected feature/logout entry: %+v", logoutEntry)
+	}
+}